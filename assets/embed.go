@@ -1 +1,9 @@
 package assets
+
+import "embed"
+
+// Migrations holds the versioned SQL migrations
+// Files are named {version}_{name}.up.sql and {version}_{name}.down.sql
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS todos (
+    id          uuid PRIMARY KEY,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    title       text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    status      text NOT NULL DEFAULT 'pending'
+);
+
+CREATE INDEX IF NOT EXISTS idx_todos_deleted_at ON todos (deleted_at);
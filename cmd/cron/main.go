@@ -1,5 +1,12 @@
 package main
 
-func main() {
+import (
+	"os"
+
+	"github.com/salihguru/idiogo/internal/cli"
+)
 
+// main is kept for existing deployments, it is the same as `idiogo cron`
+func main() {
+	os.Exit(cli.Run(append(os.Args[1:], "cron")))
 }
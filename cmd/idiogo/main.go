@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"github.com/salihguru/idiogo/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:]))
+}
@@ -0,0 +1,41 @@
+package cron
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job is a background task that runs on a fixed interval
+type Job struct {
+	Name  string
+	Every time.Duration
+	Run   func(ctx context.Context) error
+}
+
+// Run starts every job and blocks until ctx is done
+// A failing job is logged and retried on its next tick
+func Run(ctx context.Context, jobs ...Job) {
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(job.Every)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := job.Run(ctx); err != nil {
+						slog.ErrorContext(ctx, "cron job failed", "job", job.Name, "error", err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	<-ctx.Done()
+}
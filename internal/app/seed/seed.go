@@ -0,0 +1,36 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/salihguru/idiogo/internal/app/serve"
+	"github.com/salihguru/idiogo/internal/domain/todo"
+	"github.com/salihguru/idiogo/pkg/list"
+	"github.com/salihguru/idiogo/pkg/ptr"
+)
+
+var todos = []todo.CreateReq{
+	{Title: "Read the architecture guide", Description: "docs/ARCHITECTURE.md explains the layers"},
+	{Title: "Add your first domain", Description: "Copy internal/domain/todo as a starting point"},
+	{Title: "Write a migration", Description: "Add numbered up and down scripts to assets/migrations"},
+}
+
+// Run inserts sample data through the domain services
+// It does nothing if the database already has todos
+// It returns the number of created records
+func Run(ctx context.Context, a *serve.App) (int, error) {
+	srv := a.Modules.Todo.Service
+	existing, err := srv.Find(ctx, todo.ListReq{PagiRequest: list.PagiRequest{Limit: ptr.Int(1)}})
+	if err != nil {
+		return 0, err
+	}
+	if existing.Total > 0 {
+		return 0, nil
+	}
+	for _, req := range todos {
+		if _, err := srv.Create(ctx, req); err != nil {
+			return 0, err
+		}
+	}
+	return len(todos), nil
+}
@@ -2,11 +2,16 @@ package serve
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"slices"
 
 	"github.com/salihguru/idiogo/internal/config"
 	"github.com/salihguru/idiogo/internal/infra/db"
 	"github.com/salihguru/idiogo/internal/infra/db/migration"
+	"github.com/salihguru/idiogo/pkg/health"
 	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/metrics"
 	"github.com/salihguru/idiogo/pkg/validation"
 	"gorm.io/gorm"
 )
@@ -15,6 +20,34 @@ type Depends struct {
 	DB            *gorm.DB
 	ValidationSrv *validation.Srv
 	I18n          *i18np.I18n
+	Health        *health.Checker
+	Logger        *slog.Logger
+	LogLevel      *slog.LevelVar
+	Metrics       *metrics.Registry
+}
+
+// registerChecks adds a health check for every dependency that is up
+func (d *Depends) registerChecks(locales []string) {
+	if d.I18n != nil {
+		d.Health.Register("i18n", func(_ context.Context) error {
+			loaded := d.I18n.Languages()
+			for _, l := range locales {
+				if !slices.Contains(loaded, l) {
+					return fmt.Errorf("locale %s is not loaded", l)
+				}
+			}
+			return nil
+		})
+	}
+	if d.DB != nil {
+		d.Health.Register("db", func(ctx context.Context) error {
+			sql, err := d.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sql.PingContext(ctx)
+		})
+	}
 }
 
 func (d *Depends) Up(ctx context.Context, cnf config.Config) error {
@@ -26,12 +59,17 @@ func (d *Depends) Up(ctx context.Context, cnf config.Config) error {
 		DBName:   cnf.DB.Name,
 		SSLMode:  cnf.DB.SSLMode,
 		Debug:    cnf.DB.Debug,
+		Metrics:  d.Metrics,
 	})
 	if err != nil {
 		return err
 	}
 	if cnf.DB.Migrate {
 		if err := migration.RunSql(ctx, db); err != nil {
+			// the app is not built, so nothing else closes the pool
+			if sql, sqlErr := db.DB(); sqlErr == nil {
+				_ = sql.Close()
+			}
 			return err
 		}
 	}
@@ -39,10 +77,6 @@ func (d *Depends) Up(ctx context.Context, cnf config.Config) error {
 	return nil
 }
 
-func (d Depends) Shutdown(ctx context.Context) error {
-	return d.closeDB(ctx)
-}
-
 func (d Depends) closeDB(_ context.Context) error {
 	if d.DB == nil {
 		return nil
@@ -11,7 +11,7 @@ type Modules struct {
 
 func newModules(deps *Depends) Modules {
 	todoRepo := todo.NewRepo(deps.DB)
-	todoSrv := todo.NewService(todoRepo)
+	todoSrv := todo.NewService(todoRepo, deps.Metrics)
 	return Modules{
 		Todo: rest.Module[*todo.Repo, *todo.Service]{
 			Repo:    todoRepo,
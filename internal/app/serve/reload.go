@@ -0,0 +1,52 @@
+package serve
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/salihguru/idiogo/internal/config"
+	"github.com/salihguru/idiogo/internal/grpc"
+	"github.com/salihguru/idiogo/internal/rest"
+	"github.com/salihguru/idiogo/pkg/list"
+	"github.com/salihguru/idiogo/pkg/xlog"
+)
+
+// OnConfigChange applies a reloaded config to the running application
+// Locales are limited to the ones loaded on startup since their messages cannot be reloaded
+// grpcSrv is nil when the gRPC listener is disabled
+func (a *App) OnConfigChange(srv *rest.Server, grpcSrv *grpc.Server) config.Subscriber {
+	return func(c config.Change) {
+		locales := make([]string, 0, len(c.New.I18n.Locales))
+		for _, l := range c.New.I18n.Locales {
+			if slices.Contains(a.Config.I18n.Locales, l) {
+				locales = append(locales, l)
+				continue
+			}
+			slog.Warn("config reload: locale was not loaded on startup, restart required", "locale", l)
+		}
+		def := c.New.I18n.Default
+		if !slices.Contains(locales, def) {
+			slog.Warn("config reload: default locale was not loaded on startup, restart required", "locale", def)
+			def = a.Config.I18n.Default
+		}
+		if err := srv.Reload(c.New.Rest, locales, def); err != nil {
+			slog.Error("config reload: rest settings not applied", "error", err)
+		}
+		if grpcSrv != nil {
+			if err := grpcSrv.Reload(c.New.Rest.TrustedProxies, locales, def); err != nil {
+				slog.Error("config reload: grpc settings not applied", "error", err)
+			}
+		}
+		list.SetMaxLimit(c.New.Pagination.MaxLimit)
+		if c.New.Pagination.CursorSecret != c.Old.Pagination.CursorSecret {
+			list.SetCursorKey([]byte(c.New.Pagination.CursorSecret))
+		}
+		a.Deps.LogLevel.Set(xlog.ParseLevel(c.New.Log.Level))
+		if len(c.Changed) > 0 {
+			slog.Info("config reloaded", "changed", c.Changed)
+		}
+		if len(c.Restart) > 0 {
+			slog.Warn("config reload: restart required to apply changes", "keys", c.Restart)
+		}
+	}
+}
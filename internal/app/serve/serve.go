@@ -2,71 +2,214 @@ package serve
 
 import (
 	"context"
-	"sync"
-	"time"
+	"errors"
+	"log/slog"
+	"os"
 
 	"github.com/salihguru/idiogo/internal/config"
-	"github.com/salihguru/idiogo/pkg/cancel"
+	"github.com/salihguru/idiogo/internal/grpc"
+	"github.com/salihguru/idiogo/internal/rest"
+	"github.com/salihguru/idiogo/pkg/health"
 	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/lifecycle"
+	"github.com/salihguru/idiogo/pkg/list"
+	"github.com/salihguru/idiogo/pkg/metrics"
+	"github.com/salihguru/idiogo/pkg/query"
+	"github.com/salihguru/idiogo/pkg/server"
 	"github.com/salihguru/idiogo/pkg/validation"
+	"github.com/salihguru/idiogo/pkg/xlog"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+	"github.com/salihguru/idiogo/pkg/xtrace"
 )
 
 type App struct {
-	Modules Modules
-	Deps    Depends
-	Config  config.Config
+	Modules   Modules
+	Deps      Depends
+	Config    config.Config
+	Lifecycle *lifecycle.Manager
 }
 
-var once sync.Once
-var instance *App
+// Options changes how New builds the application
+type Options struct {
+	// Offline skips connecting to external dependencies like the database
+	// It is used by commands that only inspect the application, e.g. routes
+	Offline bool
 
-func Init(ctx context.Context) error {
-	var err error
-	once.Do(func() {
-		var configs config.Config
-		var i18n *i18np.I18n
-		if err = config.Bind(&configs, "./config.yaml"); err != nil {
-			return
-		}
-		i18n, err = i18np.New(i18np.Config{})
-		if err != nil {
-			return
-		}
-		i18n.Load(configs.I18n.Dir, configs.I18n.Locales...)
-		deps := Depends{
-			I18n:          i18n,
-			ValidationSrv: validation.New(i18n),
-		}
-		if err = deps.Up(ctx, configs); err != nil {
-			return
+	// SkipMigrate does not run migrations on startup even if db.migrate is set
+	SkipMigrate bool
+
+	// NoDrain skips the shutdown drain period, used by one-shot commands
+	NoDrain bool
+}
+
+// New builds the application for the given config
+func New(ctx context.Context, cnf config.Config, opts Options) (*App, error) {
+	level := new(slog.LevelVar)
+	level.Set(xlog.ParseLevel(cnf.Log.Level))
+	logger := xlog.New(xlog.Config{Format: cnf.Log.Format, Level: level})
+	slog.SetDefault(logger)
+
+	list.SetMaxLimit(cnf.Pagination.MaxLimit)
+	list.SetCursorKey([]byte(cnf.Pagination.CursorSecret))
+	if err := xrescode.Check(); err != nil {
+		return nil, err
+	}
+	i18n, err := i18np.New(i18np.Config{})
+	if err != nil {
+		return nil, err
+	}
+	i18n.Load(cnf.I18n.Dir, cnf.I18n.Locales...)
+	exporter, err := newExporter(cnf.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	if exporter != nil {
+		xtrace.SetDefault(xtrace.New(xtrace.Config{
+			Service:    cnf.Tracing.Service,
+			SampleRate: cnf.Tracing.SampleRate,
+			Exporter:   exporter,
+		}))
+	}
+	reg := metrics.NewRegistry()
+	metrics.RegisterRuntime(reg)
+	deps := Depends{
+		I18n:          i18n,
+		ValidationSrv: validation.New(i18n, query.ParamChecker{}),
+		Health:        health.New(health.DefaultTimeout),
+		Logger:        logger,
+		LogLevel:      level,
+		Metrics:       reg,
+	}
+	if !opts.Offline {
+		upCnf := cnf
+		if opts.SkipMigrate {
+			upCnf.DB.Migrate = false
 		}
-		instance = &App{
-			Modules: newModules(&deps),
-			Deps:    deps,
-			Config:  configs,
+		if err := deps.Up(ctx, upCnf); err != nil {
+			return nil, err
 		}
+	}
+	deps.registerChecks(cnf.I18n.Locales)
+	lcCnf := lifecycle.Config{
+		Drain:       cnf.Shutdown.Drain,
+		StepTimeout: cnf.Shutdown.StepTimeout,
+	}
+	if opts.NoDrain {
+		lcCnf.Drain = 0
+	}
+	lm := lifecycle.New(lcCnf)
+	lm.OnDrain(deps.Health.Drain)
+	if exporter != nil {
+		lm.Append("tracing", exporter.Shutdown)
+	}
+	if deps.DB != nil {
+		lm.Append("db", deps.closeDB)
+	}
+	return &App{
+		Modules:   newModules(&deps),
+		Deps:      deps,
+		Config:    cnf,
+		Lifecycle: lm,
+	}, nil
+}
+
+// newExporter creates the configured span exporter, nil when tracing is disabled
+func newExporter(cnf config.Tracing) (xtrace.Exporter, error) {
+	if !cnf.Enabled {
+		return nil, nil
+	}
+	if cnf.Exporter == "file" {
+		return xtrace.NewFileExporter(cnf.File)
+	}
+	return xtrace.NewWriterExporter(os.Stdout), nil
+}
+
+// RestServer creates the REST server serving every module router
+func (a *App) RestServer() *rest.Server {
+	return rest.New(rest.Config{
+		Rest:      a.Config.Rest,
+		I18n:      *a.Deps.I18n,
+		Validator: *a.Deps.ValidationSrv,
+		Locales:   a.Config.I18n.Locales,
+		Logger:    a.Deps.Logger,
+		Metrics:   a.Deps.Metrics,
+
+		DefaultLocale: a.Config.I18n.Default,
+		Routers:       append([]rest.Router{rest.NewHealthRouter(a.Deps.Health)}, a.Modules.Routers()...),
 	})
-	return err
 }
 
-func Get() *App {
-	return instance
+// GrpcServer creates the gRPC server serving the domain services
+func (a *App) GrpcServer() *grpc.Server {
+	return grpc.New(grpc.Config{
+		Grpc:           a.Config.Grpc,
+		I18n:           *a.Deps.I18n,
+		Validator:      *a.Deps.ValidationSrv,
+		Locales:        a.Config.I18n.Locales,
+		DefaultLocale:  a.Config.I18n.Default,
+		Logger:         a.Deps.Logger,
+		TrustedProxies: a.Config.Rest.TrustedProxies,
+		Todo:           a.Modules.Todo.Service,
+	})
 }
 
-type disconFunc func(context.Context) error
+// MetricsServer creates the admin listener serving the metrics registry
+func (a *App) MetricsServer() *metrics.Server {
+	cnf := a.Config.Metrics
+	return metrics.NewServer(cnf.Host, cnf.Port, cnf.Path, a.Deps.Metrics)
+}
 
-func (a *App) disconnectAll(ctx context.Context, fns ...disconFunc) error {
-	for _, fn := range fns {
-		if err := fn(ctx); err != nil {
-			return err
+// Serve runs the listeners of sup until ctx is done or one of them fails fatally
+// Then it shuts the application down: readiness fails, the drain period passes,
+// the listeners stop and the dependencies are closed
+func (a *App) Serve(ctx context.Context, sup *server.Supervisor) error {
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	var runErr error
+	finished := make(chan struct{})
+	go func() {
+		runErr = sup.Run(runCtx)
+		close(finished)
+	}()
+	a.Lifecycle.Append("servers", func(ctx context.Context) error {
+		stop()
+		select {
+		case <-finished:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
 		}
+	})
+
+	select {
+	case <-ctx.Done():
+	case <-finished:
+	}
+	slog.Info("application is shutting down")
+	report := a.Shutdown(context.Background())
+	LogReport(report)
+	select {
+	case <-finished:
+		return errors.Join(runErr, report.Err())
+	default:
+		return report.Err()
 	}
-	return nil
 }
 
-func (a *App) Shutdown(ctx context.Context, fns ...disconFunc) error {
-	return cancel.NewWithTimeout(ctx, 5*time.Second, func(ctx context.Context) error {
-		fns = append(fns, a.Deps.Shutdown)
-		return a.disconnectAll(ctx, fns...)
-	})
+// Shutdown marks the application as not ready, waits for the drain period and
+// stops every registered server and dependency in reverse start order
+func (a *App) Shutdown(ctx context.Context) lifecycle.Report {
+	return a.Lifecycle.Shutdown(ctx)
+}
+
+// LogReport logs every shutdown step of r
+func LogReport(r lifecycle.Report) {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			slog.Error("shutdown step failed", "step", step.Name, "duration", step.Duration, "error", step.Err)
+			continue
+		}
+		slog.Info("shutdown step completed", "step", step.Name, "duration", step.Duration)
+	}
+	slog.Info("shutdown completed", "duration", r.Duration)
 }
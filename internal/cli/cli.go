@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/salihguru/idiogo/internal/app/serve"
+	"github.com/salihguru/idiogo/internal/config"
+	"github.com/salihguru/idiogo/pkg/validation"
+)
+
+// Exit codes returned by Run
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// DefaultConfigPath is used when --config is not given
+const DefaultConfigPath = "./config.yaml"
+
+// initTimeout bounds the time spent connecting to dependencies on startup
+const initTimeout = 10 * time.Second
+
+// errUsage marks errors caused by invalid arguments
+var errUsage = errors.New("usage")
+
+func usageErr(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// Env is the shared state of a single CLI invocation
+type Env struct {
+	ConfigPath string
+	ConfigEnv  string
+	Stdout     io.Writer
+	Stderr     io.Writer
+
+	// LookupEnv reads the environment variables of the config, defaults to os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+}
+
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, env *Env, args []string) error
+}
+
+var commands = map[string]command{}
+
+func register(c command) {
+	commands[c.name] = c
+}
+
+// Run executes the CLI with the given arguments (without the program name)
+// It returns the process exit code
+func Run(args []string) int {
+	return (&Env{Stdout: os.Stdout, Stderr: os.Stderr}).Run(args)
+}
+
+// Run is the package Run writing to the streams of e and reading its environment variables
+func (e *Env) Run(args []string) int {
+	fs := flag.NewFlagSet("idiogo", flag.ContinueOnError)
+	fs.SetOutput(e.Stderr)
+	fs.StringVar(&e.ConfigPath, "config", DefaultConfigPath, "path of the config file")
+	fs.StringVar(&e.ConfigEnv, "env", "", "config overlay to load, e.g. prod for config.prod.yaml (default $"+config.EnvName+")")
+	fs.Usage = func() { printUsage(e.Stderr, fs) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return ExitUsage
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(e.Stderr, "unknown command %q\n\n", fs.Arg(0))
+		fs.Usage()
+		return ExitUsage
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := cmd.run(ctx, e, fs.Args()[1:]); err != nil {
+		fmt.Fprintf(e.Stderr, "idiogo %s: %v\n", cmd.name, err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(e.Stderr, "usage: idiogo %s\n", cmd.usage)
+			return ExitUsage
+		}
+		return ExitError
+	}
+	return ExitOK
+}
+
+func printUsage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintln(w, "usage: idiogo [--config path] <command> [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "flags:")
+	fs.PrintDefaults()
+}
+
+// loader reads the config file given with --config and its overlays
+func (e *Env) loader() config.Loader {
+	return config.Loader{Path: e.ConfigPath, Env: e.ConfigEnv, LookupEnv: e.LookupEnv}
+}
+
+// loadConfig reads and validates the layered config
+func (e *Env) loadConfig() (config.Config, error) {
+	var cnf config.Config
+	if _, err := e.loader().Load(&cnf); err != nil {
+		return cnf, err
+	}
+	if err := config.Validate(context.Background(), validation.New(nil), cnf); err != nil {
+		return cnf, err
+	}
+	return cnf, nil
+}
+
+// newApp loads the config and builds the application
+func (e *Env) newApp(ctx context.Context, opts serve.Options) (*serve.App, error) {
+	cnf, err := e.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, initTimeout)
+	defer cancel()
+	return serve.New(ctx, cnf, opts)
+}
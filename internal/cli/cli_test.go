@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salihguru/idiogo/internal/config"
+	"go.yaml.in/yaml/v2"
+)
+
+const testConfig = `db:
+  host: localhost
+  user: idiogo
+  pass: ${PGPASS}
+  name: idiogo
+i18n:
+  locales: [en, tr]
+`
+
+// newTestEnv returns an env writing to buffers and reading the environment variables of vars
+func newTestEnv(vars map[string]string) (*Env, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	env := &Env{
+		Stdout: &stdout,
+		Stderr: &stderr,
+		LookupEnv: func(key string) (string, bool) {
+			v, ok := vars[key]
+			return v, ok
+		},
+	}
+	return env, &stdout, &stderr
+}
+
+// writeConfig writes config.yaml with the prod overlay into a temporary directory and returns the base path
+func writeConfig(t *testing.T, base, prod string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{"config.yaml": base, "config.prod.yaml": prod}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{name: "no command", code: ExitUsage, stderr: "usage: idiogo [--config path] <command>"},
+		{name: "help", args: []string{"--help"}, code: ExitOK, stderr: "migrate up | down [N] | redo | status"},
+		{name: "unknown flag", args: []string{"--nope", "serve"}, code: ExitUsage, stderr: "flag provided but not defined: -nope"},
+		{name: "unknown command", args: []string{"nope"}, code: ExitUsage, stderr: `unknown command "nope"`},
+		{name: "flag after command", args: []string{"routes", "--config", "x.yaml"}, code: ExitUsage, stderr: "unexpected arguments [--config x.yaml]"},
+		{name: "serve arguments", args: []string{"serve", "now"}, code: ExitUsage, stderr: "usage: idiogo serve"},
+		{name: "cron arguments", args: []string{"cron", "now"}, code: ExitUsage, stderr: "usage: idiogo cron"},
+		{name: "seed arguments", args: []string{"seed", "now"}, code: ExitUsage, stderr: "usage: idiogo seed"},
+		{name: "openapi arguments", args: []string{"openapi", "json"}, code: ExitUsage, stderr: "unexpected arguments [json]"},
+		{name: "migrate without operation", args: []string{"migrate"}, code: ExitUsage, stderr: "missing migrate operation"},
+		{name: "migrate unknown operation", args: []string{"migrate", "sideways"}, code: ExitUsage, stderr: `unknown migrate operation "sideways"`},
+		{name: "migrate up arguments", args: []string{"migrate", "up", "2"}, code: ExitUsage, stderr: "unexpected arguments [2]"},
+		{name: "migrate redo arguments", args: []string{"migrate", "redo", "2"}, code: ExitUsage, stderr: "unexpected arguments [2]"},
+		{name: "migrate status arguments", args: []string{"migrate", "status", "all"}, code: ExitUsage, stderr: "unexpected arguments [all]"},
+		{name: "migrate down zero", args: []string{"migrate", "down", "0"}, code: ExitUsage, stderr: `invalid step count "0"`},
+		{name: "migrate down negative", args: []string{"migrate", "down", "-1"}, code: ExitUsage, stderr: `invalid step count "-1"`},
+		{name: "migrate down text", args: []string{"migrate", "down", "all"}, code: ExitUsage, stderr: `invalid step count "all"`},
+		{name: "migrate down two counts", args: []string{"migrate", "down", "1", "2"}, code: ExitUsage, stderr: "unexpected arguments [2]"},
+		{name: "config without operation", args: []string{"config"}, code: ExitUsage, stderr: "expected exactly one config operation"},
+		{name: "config unknown operation", args: []string{"config", "edit"}, code: ExitUsage, stderr: `unknown config operation "edit"`},
+		{name: "i18n without check", args: []string{"i18n"}, code: ExitUsage, stderr: "expected the check operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, stdout, stderr := newTestEnv(nil)
+			if code := env.Run(tt.args); code != tt.code {
+				t.Errorf("Run(%v) = %d, want %d\n%s", tt.args, code, tt.code, stderr)
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr, tt.stderr)
+			}
+			if stdout.Len() > 0 {
+				t.Errorf("stdout = %q, want nothing", stdout)
+			}
+		})
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	path := writeConfig(t, testConfig, "rest:\n  port: \"8080\"\n")
+	overlay := filepath.Join(filepath.Dir(path), "config.prod.yaml")
+	vars := map[string]string{"PGPASS": "s3cret", "IDIOGO_LOG_LEVEL": "debug"}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout []string
+	}{
+		{name: "validate", args: []string{"--config", path, "config", "validate"}, code: ExitOK, stdout: []string{path + " is valid"}},
+		{name: "sources", args: []string{"--config", path, "--env", "prod", "config", "sources"}, code: ExitOK, stdout: []string{
+			"db.pass file (" + path + ") via ${PGPASS}",
+			"log.level env (IDIOGO_LOG_LEVEL)",
+			"rest.port overlay (" + overlay + ")",
+			"rest.host default",
+		}},
+		{name: "missing file", args: []string{"--config", path + ".missing", "config", "validate"}, code: ExitError},
+		{name: "invalid", args: []string{"--config", writeConfig(t, "db:\n  host: localhost\n", ""), "config", "validate"}, code: ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, stdout, stderr := newTestEnv(vars)
+			if code := env.Run(tt.args); code != tt.code {
+				t.Fatalf("Run(%v) = %d, want %d\n%s", tt.args, code, tt.code, stderr)
+			}
+			// the tables are padded, compare the words only
+			got := strings.Join(strings.Fields(stdout.String()), " ")
+			for _, want := range tt.stdout {
+				if !strings.Contains(got, want) {
+					t.Errorf("stdout = %q, want it to contain %q", stdout, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunConfigPrint(t *testing.T) {
+	path := writeConfig(t, testConfig+"pagination:\n  cursor_secret: k3y\n", "rest:\n  port: \"8080\"\n")
+	env, stdout, stderr := newTestEnv(map[string]string{"PGPASS": "s3cret", "IDIOGO_ENV": "prod", "IDIOGO_LOG_LEVEL": "debug"})
+	if code := env.Run([]string{"--config", path, "config", "print"}); code != ExitOK {
+		t.Fatalf("Run() = %d, want %d\n%s", code, ExitOK, stderr)
+	}
+	if strings.Contains(stdout.String(), "s3cret") || strings.Contains(stdout.String(), "k3y") {
+		t.Fatalf("config print shows a secret:\n%s", stdout)
+	}
+	var cnf config.Config
+	if err := yaml.Unmarshal(stdout.Bytes(), &cnf); err != nil {
+		t.Fatalf("config print isn't yaml: %v\n%s", err, stdout)
+	}
+	checks := []struct {
+		key, got, want string
+	}{
+		{"db.host", cnf.DB.Host, "localhost"},
+		{"db.pass", cnf.DB.Pass, secretMask},
+		{"pagination.cursor_secret", cnf.Pagination.CursorSecret, secretMask},
+		{"rest.port", cnf.Rest.Port, "8080"},
+		{"log.level", cnf.Log.Level, "debug"},
+		{"db.port", cnf.DB.Port, "5432"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.key, c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/salihguru/idiogo/internal/config"
+	"go.yaml.in/yaml/v2"
+)
+
+// secretMask replaces secret values in config print
+const secretMask = "******"
+
+func init() {
+	register(command{name: "config", usage: "config validate | print | sources", run: runConfig})
+}
+
+func runConfig(ctx context.Context, env *Env, args []string) error {
+	if len(args) != 1 {
+		return usageErr("expected exactly one config operation")
+	}
+	switch args[0] {
+	case "validate":
+		if _, err := env.loadConfig(); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Stdout, "%s is valid\n", env.ConfigPath)
+		return nil
+	case "print":
+		cnf, err := env.loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := config.MaskSecrets(&cnf, secretMask); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(cnf)
+		if err != nil {
+			return err
+		}
+		_, err = env.Stdout.Write(out)
+		return err
+	case "sources":
+		var cnf config.Config
+		sources, err := env.loader().Load(&cnf)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(env.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tSOURCE")
+		for _, path := range sources.Paths() {
+			fmt.Fprintf(w, "%s\t%s\n", path, sources[path])
+		}
+		return w.Flush()
+	default:
+		return usageErr("unknown config operation %q", args[0])
+	}
+}
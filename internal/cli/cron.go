@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/salihguru/idiogo/internal/app/cron"
+	"github.com/salihguru/idiogo/internal/app/serve"
+)
+
+func init() {
+	register(command{name: "cron", usage: "cron", run: runCron})
+}
+
+func runCron(ctx context.Context, env *Env, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	}
+	a, err := env.newApp(ctx, serve.Options{SkipMigrate: true, NoDrain: true})
+	if err != nil {
+		return err
+	}
+	slog.Info("cron is running")
+	a.Lifecycle.Go(func(ctx context.Context) { cron.Run(ctx) })
+	<-ctx.Done()
+	slog.Info("cron is shutting down")
+	report := a.Shutdown(context.Background())
+	serve.LogReport(report)
+	return report.Err()
+}
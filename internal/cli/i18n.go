@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+)
+
+func init() {
+	register(command{name: "i18n", usage: "i18n check", run: runI18n})
+}
+
+func runI18n(ctx context.Context, env *Env, args []string) error {
+	if len(args) != 1 || args[0] != "check" {
+		return usageErr("expected the check operation")
+	}
+	cnf, err := env.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := xrescode.Check(); err != nil {
+		return err
+	}
+	i18n, err := i18np.New(i18np.Config{})
+	if err != nil {
+		return err
+	}
+	i18n.Load(cnf.I18n.Dir, cnf.I18n.Locales...)
+
+	w := tabwriter.NewWriter(env.Stdout, 0, 0, 2, ' ', 0)
+	missing := 0
+	for _, r := range xrescode.Results() {
+		for _, locale := range cnf.I18n.Locales {
+			if i18n.Has(r.Message, locale) {
+				continue
+			}
+			if missing == 0 {
+				fmt.Fprintln(w, "LOCALE\tKEY\tCODE\tDOMAIN")
+			}
+			missing++
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", locale, r.Message, r.Code, r.Domain)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if missing > 0 {
+		return fmt.Errorf("%d translations are missing", missing)
+	}
+	fmt.Fprintf(env.Stdout, "every result code is translated in %v\n", cnf.I18n.Locales)
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/salihguru/idiogo/internal/app/serve"
+	"github.com/salihguru/idiogo/internal/infra/db/migration"
+)
+
+func init() {
+	register(command{name: "migrate", usage: "migrate up | down [N] | redo | status", run: runMigrate})
+}
+
+func runMigrate(ctx context.Context, env *Env, args []string) error {
+	if len(args) == 0 {
+		return usageErr("missing migrate operation")
+	}
+	op, args := args[0], args[1:]
+	steps := 1
+	switch op {
+	case "up", "redo", "status":
+		if len(args) > 0 {
+			return usageErr("unexpected arguments %v", args)
+		}
+	case "down":
+		if len(args) > 1 {
+			return usageErr("unexpected arguments %v", args[1:])
+		}
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return usageErr("invalid step count %q", args[0])
+			}
+			steps = n
+		}
+	default:
+		return usageErr("unknown migrate operation %q", op)
+	}
+
+	a, err := env.newApp(ctx, serve.Options{SkipMigrate: true, NoDrain: true})
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown(context.Background())
+	m, err := migration.NewFromGorm(a.Deps.DB)
+	if err != nil {
+		return err
+	}
+
+	switch op {
+	case "up":
+		done, err := m.Up(ctx)
+		printMigrations(env, "applied", done)
+		return err
+	case "down":
+		done, err := m.Down(ctx, steps)
+		printMigrations(env, "rolled back", done)
+		return err
+	case "redo":
+		mig, err := m.Redo(ctx)
+		if mig != nil {
+			printMigrations(env, "redone", []migration.Migration{*mig})
+		}
+		return err
+	default:
+		return printStatus(ctx, env, m)
+	}
+}
+
+func printMigrations(env *Env, action string, list []migration.Migration) {
+	if len(list) == 0 {
+		fmt.Fprintf(env.Stdout, "no migrations %s\n", action)
+		return
+	}
+	for _, mig := range list {
+		fmt.Fprintf(env.Stdout, "%s %d_%s\n", action, mig.Version, mig.Name)
+	}
+}
+
+func printStatus(ctx context.Context, env *Env, m *migration.Migrator) error {
+	list, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(env.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, st := range list {
+		status, appliedAt := "pending", "-"
+		if st.Applied {
+			status = "applied"
+			appliedAt = st.AppliedAt.Format(time.RFC3339)
+		}
+		if st.Modified {
+			status = "modified"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", st.Version, st.Name, status, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/salihguru/idiogo/internal/app/serve"
+)
+
+func init() {
+	register(command{name: "openapi", usage: "openapi", run: runOpenAPI})
+}
+
+// runOpenAPI prints the OpenAPI document served at /openapi.json
+func runOpenAPI(ctx context.Context, env *Env, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	}
+	a, err := env.newApp(ctx, serve.Options{Offline: true})
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(env.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a.RestServer().OpenAPI())
+}
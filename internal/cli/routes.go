@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/salihguru/idiogo/internal/app/serve"
+)
+
+func init() {
+	register(command{name: "routes", usage: "routes", run: runRoutes})
+}
+
+func runRoutes(ctx context.Context, env *Env, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	}
+	a, err := env.newApp(ctx, serve.Options{Offline: true})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(env.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tHANDLERS")
+	for _, r := range a.RestServer().Routes() {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", r.Method, r.Path, len(r.Handlers))
+	}
+	return w.Flush()
+}
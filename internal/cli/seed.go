@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salihguru/idiogo/internal/app/seed"
+	"github.com/salihguru/idiogo/internal/app/serve"
+)
+
+func init() {
+	register(command{name: "seed", usage: "seed", run: runSeed})
+}
+
+func runSeed(ctx context.Context, env *Env, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	}
+	a, err := env.newApp(ctx, serve.Options{NoDrain: true})
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown(context.Background())
+	n, err := seed.Run(ctx, a)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Stdout, "seeded %d records\n", n)
+	return nil
+}
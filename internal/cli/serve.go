@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/salihguru/idiogo/internal/app/serve"
+	"github.com/salihguru/idiogo/internal/config"
+	"github.com/salihguru/idiogo/internal/grpc"
+	"github.com/salihguru/idiogo/pkg/server"
+	"github.com/salihguru/idiogo/pkg/validation"
+)
+
+// configPollInterval is how often the config files are checked for changes
+const configPollInterval = 2 * time.Second
+
+func init() {
+	register(command{name: "serve", usage: "serve", run: runServe})
+}
+
+func runServe(ctx context.Context, env *Env, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	}
+	a, err := env.newApp(ctx, serve.Options{})
+	if err != nil {
+		return err
+	}
+	restServer := a.RestServer()
+	var grpcServer *grpc.Server
+	if a.Config.Grpc.Enabled {
+		grpcServer = a.GrpcServer()
+	}
+	watcher := config.NewWatcher(env.loader(), a.Config, func(c config.Config) error {
+		return config.Validate(ctx, validation.New(nil), c)
+	})
+	watcher.Subscribe(a.OnConfigChange(restServer, grpcServer))
+	a.Lifecycle.Go(func(ctx context.Context) { watcher.Watch(ctx, configPollInterval) })
+	a.Lifecycle.Go(func(ctx context.Context) { reloadOnHangup(ctx, watcher) })
+
+	sup := server.NewSupervisor(server.SupervisorConfig{ShutdownTimeout: a.Config.Shutdown.StepTimeout})
+	sup.Add("rest", restServer)
+	if grpcServer != nil {
+		sup.Add("grpc", grpcServer)
+	}
+	if a.Config.Metrics.Enabled {
+		sup.Add("metrics", a.MetricsServer())
+	}
+	return a.Serve(ctx, sup)
+}
+
+// reloadOnHangup reloads the config on every SIGHUP until ctx is done
+func reloadOnHangup(ctx context.Context, w *config.Watcher) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if err := w.Reload(); err != nil {
+				slog.Error("config reload failed, keeping the running config", "error", err)
+			}
+		}
+	}
+}
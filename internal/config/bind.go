@@ -1,24 +1,402 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
 	"go.yaml.in/yaml/v2"
 )
 
+const (
+	// EnvPrefix is the prefix of environment variables mapped onto the config
+	// example: IDIOGO_DB_PASS sets db.pass
+	EnvPrefix = "IDIOGO"
+
+	// EnvName selects the environment overlay, e.g. IDIOGO_ENV=prod loads config.prod.yaml
+	EnvName = EnvPrefix + "_ENV"
+
+	// filePrefix marks a value that is read from a file, e.g. file:/run/secrets/db_pass
+	filePrefix = "file:"
+)
+
+// Layers a final config value can come from
+const (
+	LayerDefault = "default"
+	LayerFile    = "file"
+	LayerOverlay = "overlay"
+	LayerEnv     = "env"
+)
+
+// envRefRegexp matches ${VAR} and ${VAR:-default} references inside values
+// A ${VAR} reference fails to load when VAR is unset or empty
+var envRefRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// Source describes where a config value came from
+type Source struct {
+	// Layer is one of LayerDefault, LayerFile, LayerOverlay or LayerEnv
+	Layer string
+
+	// Origin is the file path or the environment variable name
+	Origin string
+
+	// Ref is the ${ENV} or file: reference the value was resolved from, if any
+	Ref string
+}
+
+func (s Source) String() string {
+	if s.Ref != "" {
+		return fmt.Sprintf("%s (%s) via %s", s.Layer, s.Origin, s.Ref)
+	}
+	if s.Origin == "" {
+		return s.Layer
+	}
+	return fmt.Sprintf("%s (%s)", s.Layer, s.Origin)
+}
+
+// Sources maps yaml paths like db.pass to the source of their final value
+type Sources map[string]Source
+
+// Paths returns the yaml paths in sorted order
+func (s Sources) Paths() []string {
+	paths := make([]string, 0, len(s))
+	for p := range s {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Loader reads the config in layers, each one overriding the previous:
+//  1. the base file at Path
+//  2. the environment overlay next to it, e.g. config.prod.yaml
+//  3. environment variables prefixed with Prefix
+//
+// After merging, ${ENV} and file: references in string values are resolved
+// and fields no layer sets are filled from their `default` tags, an explicit zero is kept.
+type Loader struct {
+	// Path is the base config file, it must exist
+	Path string
+
+	// Env is the environment overlay name, defaults to $IDIOGO_ENV
+	Env string
+
+	// Prefix is the environment variable prefix, defaults to EnvPrefix
+	Prefix string
+
+	// LookupEnv reads environment variables, defaults to os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+}
+
+// Files returns the config files the loader reads, the overlay is included only if it exists
+func (l Loader) Files() []string {
+	files := []string{l.Path}
+	if overlay := l.overlayPath(); overlay != "" {
+		if _, err := os.Stat(overlay); err == nil {
+			files = append(files, overlay)
+		}
+	}
+	return files
+}
+
+// Load binds the layered config into v which must be a pointer to a struct
+func (l Loader) Load(v interface{}) (Sources, error) {
+	l = l.withDefaults()
+	tree := make(map[string]interface{})
+	sources := make(Sources)
+
+	base, err := readYaml(l.Path, true)
+	if err != nil {
+		return nil, err
+	}
+	merge(tree, base, nil, sources, Source{Layer: LayerFile, Origin: l.Path})
+
+	if overlay := l.overlayPath(); overlay != "" {
+		over, err := readYaml(overlay, false)
+		if err != nil {
+			return nil, err
+		}
+		merge(tree, over, nil, sources, Source{Layer: LayerOverlay, Origin: overlay})
+	}
+
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
+		for _, f := range fieldsOf(t.Elem(), nil) {
+			name := l.Prefix + "_" + strings.ToUpper(strings.Join(f.path, "_"))
+			val, ok := l.LookupEnv(name)
+			if !ok {
+				continue
+			}
+			set(tree, f.path, envValue(val, f.kind))
+			sources[strings.Join(f.path, ".")] = Source{Layer: LayerEnv, Origin: name}
+		}
+	}
+
+	if err := l.resolve(tree, nil, sources); err != nil {
+		return nil, err
+	}
+	out, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(out, v); err != nil {
+		return nil, err
+	}
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
+		defaulted, err := applyDefaultsExcept(v, explicit(tree, sources))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range defaulted {
+			sources[path] = Source{Layer: LayerDefault}
+		}
+	}
+	return sources, nil
+}
+
+// Bind loads the layered config starting from the base file at path
 func Bind(v interface{}, path string) error {
+	_, err := Loader{Path: path}.Load(v)
+	return err
+}
+
+func (l Loader) withDefaults() Loader {
+	if l.LookupEnv == nil {
+		l.LookupEnv = os.LookupEnv
+	}
+	if l.Prefix == "" {
+		l.Prefix = EnvPrefix
+	}
+	if l.Env == "" {
+		l.Env, _ = l.LookupEnv(EnvName)
+	}
+	return l
+}
+
+func (l Loader) overlayPath() string {
+	l = l.withDefaults()
+	if l.Env == "" {
+		return ""
+	}
+	ext := filepath.Ext(l.Path)
+	return strings.TrimSuffix(l.Path, ext) + "." + l.Env + ext
+}
+
+// resolve replaces ${ENV} and file: references in every string value
+func (l Loader) resolve(node interface{}, path []string, sources Sources) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, child := range n {
+			p := append(append([]string{}, path...), k)
+			if s, ok := child.(string); ok {
+				val, ref, err := l.resolveValue(s)
+				if err != nil {
+					return fmt.Errorf("config %s: %w", strings.Join(p, "."), err)
+				}
+				if ref != "" {
+					n[k] = val
+					key := strings.Join(p, ".")
+					src := sources[key]
+					src.Ref = ref
+					sources[key] = src
+				}
+				continue
+			}
+			if err := l.resolve(child, p, sources); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, child := range n {
+			s, ok := child.(string)
+			if !ok {
+				continue
+			}
+			val, _, err := l.resolveValue(s)
+			if err != nil {
+				return fmt.Errorf("config %s[%d]: %w", strings.Join(path, "."), i, err)
+			}
+			n[i] = val
+		}
+	}
+	return nil
+}
+
+func (l Loader) resolveValue(s string) (string, string, error) {
+	if strings.HasPrefix(s, filePrefix) {
+		name := strings.TrimPrefix(s, filePrefix)
+		content, err := os.ReadFile(filepath.Clean(name))
+		if err != nil {
+			return "", "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), s, nil
+	}
+	if !envRefRegexp.MatchString(s) {
+		return s, "", nil
+	}
+	var missing []string
+	val := envRefRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		match := envRefRegexp.FindStringSubmatch(m)
+		if v, ok := l.LookupEnv(match[1]); ok && v != "" {
+			return v
+		}
+		if !strings.Contains(m, ":-") {
+			missing = append(missing, match[1])
+		}
+		return match[2]
+	})
+	if len(missing) > 0 {
+		return "", "", fmt.Errorf("environment variable not set: %s, use ${%s:-} to allow an empty value", strings.Join(missing, ", "), missing[0])
+	}
+	return val, s, nil
+}
+
+func readYaml(path string, required bool) (map[string]interface{}, error) {
 	filename, err := filepath.Abs(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	cleanedDst := filepath.Clean(filename)
-	yamlFile, err := os.ReadFile(cleanedDst)
+	content, err := os.ReadFile(filepath.Clean(filename))
 	if err != nil {
-		return err
+		if !required && os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	if err := yaml.Unmarshal(yamlFile, v); err != nil {
-		return err
+	var raw map[interface{}]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
-	return nil
+	m, _ := normalize(raw).(map[string]interface{})
+	return m, nil
+}
+
+// normalize converts the map[interface{}]interface{} produced by yaml into map[string]interface{}
+func normalize(v interface{}) interface{} {
+	switch n := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(n))
+		for k, child := range n {
+			m[fmt.Sprint(k)] = normalize(child)
+		}
+		return m
+	case []interface{}:
+		for i, child := range n {
+			n[i] = normalize(child)
+		}
+		return n
+	}
+	return v
+}
+
+// merge copies src over dst and records src as the source of every leaf it sets
+func merge(dst, src map[string]interface{}, path []string, sources Sources, source Source) {
+	for k, v := range src {
+		p := append(append([]string{}, path...), k)
+		if child, ok := v.(map[string]interface{}); ok {
+			existing, ok := dst[k].(map[string]interface{})
+			if !ok {
+				existing = make(map[string]interface{})
+				dst[k] = existing
+			}
+			merge(existing, child, p, sources, source)
+			continue
+		}
+		dst[k] = v
+		sources[strings.Join(p, ".")] = source
+	}
+}
+
+// explicit returns the paths a layer sets to a value
+// A null value or a reference resolved to an empty string doesn't count, the default applies to them
+func explicit(tree map[string]interface{}, sources Sources) map[string]bool {
+	paths := make(map[string]bool, len(sources))
+	for key, src := range sources {
+		v, ok := get(tree, strings.Split(key, "."))
+		if !ok || v == nil || (src.Ref != "" && v == "") {
+			continue
+		}
+		paths[key] = true
+	}
+	return paths
+}
+
+func get(tree map[string]interface{}, path []string) (interface{}, bool) {
+	for _, k := range path[:len(path)-1] {
+		child, ok := tree[k].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		tree = child
+	}
+	v, ok := tree[path[len(path)-1]]
+	return v, ok
+}
+
+func set(tree map[string]interface{}, path []string, v interface{}) {
+	for _, k := range path[:len(path)-1] {
+		child, ok := tree[k].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			tree[k] = child
+		}
+		tree = child
+	}
+	tree[path[len(path)-1]] = v
+}
+
+type field struct {
+	path []string
+	kind reflect.Kind
+}
+
+// fieldsOf lists the yaml paths of every leaf field of t
+func fieldsOf(t reflect.Type, path []string) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		p := append(append([]string{}, path...), name)
+		if sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, fieldsOf(sf.Type, p)...)
+			continue
+		}
+		fields = append(fields, field{path: p, kind: sf.Type.Kind()})
+	}
+	return fields
+}
+
+// envValue converts an environment variable into a yaml value for a field of the given kind
+// Slices are comma separated, strings are kept as is and other kinds are parsed as yaml scalars
+func envValue(val string, kind reflect.Kind) interface{} {
+	switch kind {
+	case reflect.String:
+		return val
+	case reflect.Slice, reflect.Array:
+		parts := strings.Split(val, ",")
+		list := make([]interface{}, 0, len(parts))
+		for _, p := range parts {
+			if p = strings.TrimSpace(p); p != "" {
+				list = append(list, p)
+			}
+		}
+		return list
+	}
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(val), &v); err != nil {
+		return val
+	}
+	return v
 }
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFiles writes the files into a temporary directory and returns its path
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// lookup reads environment variables from env instead of the process
+func lookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"config.yaml":      "db:\n  host: file-host\n  user: file-user\n  name: file-name\nrest:\n  port: \"1000\"\n",
+		"config.prod.yaml": "db:\n  host: overlay-host\n  user: overlay-user\n",
+	})
+	path := filepath.Join(dir, "config.yaml")
+	overlay := filepath.Join(dir, "config.prod.yaml")
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		loader  Loader
+		want    map[string]string
+		sources map[string]Source
+	}{
+		{
+			name: "file only",
+			env:  map[string]string{},
+			want: map[string]string{"db.host": "file-host", "db.user": "file-user", "db.name": "file-name"},
+			sources: map[string]Source{
+				"db.host": {Layer: LayerFile, Origin: path},
+				"db.port": {Layer: LayerDefault},
+			},
+		},
+		{
+			name:   "overlay from loader",
+			env:    map[string]string{},
+			loader: Loader{Env: "prod"},
+			want:   map[string]string{"db.host": "overlay-host", "db.user": "overlay-user", "db.name": "file-name"},
+			sources: map[string]Source{
+				"db.host": {Layer: LayerOverlay, Origin: overlay},
+				"db.name": {Layer: LayerFile, Origin: path},
+			},
+		},
+		{
+			name: "overlay from env name",
+			env:  map[string]string{EnvName: "prod"},
+			want: map[string]string{"db.host": "overlay-host", "db.name": "file-name"},
+		},
+		{
+			name: "missing overlay",
+			env:  map[string]string{EnvName: "staging"},
+			want: map[string]string{"db.host": "file-host"},
+		},
+		{
+			name: "env over overlay",
+			env:  map[string]string{EnvName: "prod", "IDIOGO_DB_HOST": "env-host"},
+			want: map[string]string{"db.host": "env-host", "db.user": "overlay-user", "db.name": "file-name"},
+			sources: map[string]Source{
+				"db.host": {Layer: LayerEnv, Origin: "IDIOGO_DB_HOST"},
+				"db.user": {Layer: LayerOverlay, Origin: overlay},
+			},
+		},
+		{
+			name:   "custom prefix",
+			env:    map[string]string{"APP_DB_HOST": "app-host", "IDIOGO_DB_HOST": "ignored"},
+			loader: Loader{Prefix: "APP"},
+			want:   map[string]string{"db.host": "app-host"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := tt.loader
+			l.Path = path
+			l.LookupEnv = lookup(tt.env)
+			var cnf Config
+			sources, err := l.Load(&cnf)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			got := map[string]string{"db.host": cnf.DB.Host, "db.user": cnf.DB.User, "db.name": cnf.DB.Name}
+			for k, want := range tt.want {
+				if got[k] != want {
+					t.Errorf("%s = %q, want %q", k, got[k], want)
+				}
+			}
+			for k, want := range tt.sources {
+				if sources[k] != want {
+					t.Errorf("source of %s = %v, want %v", k, sources[k], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadEnvMapping(t *testing.T) {
+	path := filepath.Join(writeFiles(t, map[string]string{"config.yaml": "rest:\n  port: \"1000\"\n"}), "config.yaml")
+	var cnf Config
+	_, err := Loader{Path: path, LookupEnv: lookup(map[string]string{
+		"IDIOGO_I18N_LOCALES":                "en, tr,,de",
+		"IDIOGO_REST_PORT":                   "8080",
+		"IDIOGO_REST_RATE_LIMIT":             "50",
+		"IDIOGO_DB_DEBUG":                    "true",
+		"IDIOGO_DB_PASS":                     "007",
+		"IDIOGO_REST_ACCESS_LOG_SAMPLE_RATE": "0.5",
+		"IDIOGO_SHUTDOWN_DRAIN":              "2s",
+	})}.Load(&cnf)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := []string{"en", "tr", "de"}; !reflect.DeepEqual(cnf.I18n.Locales, want) {
+		t.Errorf("i18n.locales = %v, want %v", cnf.I18n.Locales, want)
+	}
+	if cnf.Rest.Port != "8080" {
+		t.Errorf("rest.port = %q, want 8080", cnf.Rest.Port)
+	}
+	if cnf.Rest.RateLimit != 50 {
+		t.Errorf("rest.rate_limit = %d, want 50", cnf.Rest.RateLimit)
+	}
+	if !cnf.DB.Debug {
+		t.Error("db.debug = false, want true")
+	}
+	if cnf.DB.Pass != "007" {
+		t.Errorf("db.pass = %q, want the string kept as is", cnf.DB.Pass)
+	}
+	if cnf.Rest.AccessLog.SampleRate != 0.5 {
+		t.Errorf("rest.access_log.sample_rate = %v, want 0.5", cnf.Rest.AccessLog.SampleRate)
+	}
+	if cnf.Shutdown.Drain != 2*time.Second {
+		t.Errorf("shutdown.drain = %v, want 2s", cnf.Shutdown.Drain)
+	}
+}
+
+func TestLoadReferences(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"secret": "s3cret\n"})
+	secret := filepath.Join(dir, "secret")
+
+	tests := []struct {
+		name  string
+		value string
+		env   map[string]string
+		want  string
+		ref   bool
+		err   string
+	}{
+		{name: "plain", value: "postgres", want: "postgres"},
+		{name: "env", value: "${DB_PASS}", env: map[string]string{"DB_PASS": "pass"}, want: "pass", ref: true},
+		{name: "env default", value: "${DB_PASS:-fallback}", want: "fallback", ref: true},
+		{name: "empty env uses default", value: "${DB_PASS:-fallback}", env: map[string]string{"DB_PASS": ""}, want: "fallback", ref: true},
+		{name: "unset without default", value: "${DB_PASS}", err: "config db.pass: environment variable not set: DB_PASS,"},
+		{name: "empty without default", value: "${DB_PASS}", env: map[string]string{"DB_PASS": ""}, err: "environment variable not set: DB_PASS,"},
+		{name: "every unset variable", value: "${A}-${B:-b}-${C}", err: "environment variable not set: A, C,"},
+		{name: "empty default", value: "${DB_PASS:-}", want: "", ref: true},
+		{name: "embedded", value: "a-${A}-${B:-b}", env: map[string]string{"A": "x"}, want: "a-x-b", ref: true},
+		{name: "file", value: "file:" + secret, want: "s3cret", ref: true},
+		{name: "missing file", value: "file:" + filepath.Join(dir, "missing"), err: "config db.pass:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(writeFiles(t, map[string]string{
+				"config.yaml": "db:\n  pass: '" + tt.value + "'\n",
+			}), "config.yaml")
+			var cnf Config
+			sources, err := Loader{Path: path, LookupEnv: lookup(tt.env)}.Load(&cnf)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cnf.DB.Pass != tt.want {
+				t.Errorf("db.pass = %q, want %q", cnf.DB.Pass, tt.want)
+			}
+			src := sources["db.pass"]
+			if src.Layer != LayerFile {
+				t.Errorf("layer = %s, want %s", src.Layer, LayerFile)
+			}
+			if (src.Ref != "") != tt.ref || (tt.ref && src.Ref != tt.value) {
+				t.Errorf("ref = %q, want %v", src.Ref, tt.ref)
+			}
+		})
+	}
+}
+
+func TestLoadEnvReference(t *testing.T) {
+	path := filepath.Join(writeFiles(t, map[string]string{"config.yaml": "db:\n  host: localhost\n"}), "config.yaml")
+	var cnf Config
+	sources, err := Loader{Path: path, LookupEnv: lookup(map[string]string{
+		"IDIOGO_DB_HOST":      "${PGHOST:-db}",
+		"IDIOGO_I18N_LOCALES": "${FIRST:-en},tr",
+	})}.Load(&cnf)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cnf.DB.Host != "db" {
+		t.Errorf("db.host = %q, want db", cnf.DB.Host)
+	}
+	if want := (Source{Layer: LayerEnv, Origin: "IDIOGO_DB_HOST", Ref: "${PGHOST:-db}"}); sources["db.host"] != want {
+		t.Errorf("source = %v, want %v", sources["db.host"], want)
+	}
+	if want := []string{"en", "tr"}; !reflect.DeepEqual(cnf.I18n.Locales, want) {
+		t.Errorf("i18n.locales = %v, want %v", cnf.I18n.Locales, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"bad.yaml":      "db: [",
+		"config.yaml":   "db:\n  host: a\n",
+		"config.x.yaml": "db: [",
+	})
+	tests := map[string]Loader{
+		"missing base": {Path: filepath.Join(dir, "missing.yaml")},
+		"bad base":     {Path: filepath.Join(dir, "bad.yaml")},
+		"bad overlay":  {Path: filepath.Join(dir, "config.yaml"), Env: "x"},
+	}
+	for name, l := range tests {
+		t.Run(name, func(t *testing.T) {
+			l.LookupEnv = lookup(nil)
+			var cnf Config
+			if _, err := l.Load(&cnf); err == nil {
+				t.Error("Load() error = nil")
+			}
+		})
+	}
+}
@@ -1,29 +1,138 @@
 package config
 
+import "time"
+
+// Fields tagged `reload:"restart"` are only read on startup
+// Changing them in a running process is reported by the Watcher instead of applied
+// Fields tagged `secret:"true"` are masked by `idiogo config print`
+
 type I18n struct {
-	Locales []string `yaml:"locales"`
-	Default string   `yaml:"default"`
-	Dir     string   `yaml:"dir"`
+	Locales []string `yaml:"locales" default:"en" validate:"required,min=1,dive,locale"`
+	Default string   `yaml:"default" default:"en" validate:"required,locale,infield=Locales"`
+	Dir     string   `yaml:"dir" default:"./assets/locales" validate:"required" reload:"restart"`
 }
 
 type Database struct {
-	Host    string `yaml:"host"`
-	Port    string `yaml:"port"`
-	User    string `yaml:"user"`
-	Pass    string `yaml:"pass"`
-	Name    string `yaml:"name"`
-	Debug   bool   `yaml:"debug"`
-	SSLMode string `yaml:"ssl_mode"`
-	Migrate bool   `yaml:"migrate"`
+	Host    string `yaml:"host" validate:"required" reload:"restart"`
+	Port    string `yaml:"port" default:"5432" validate:"required,numeric" reload:"restart"`
+	User    string `yaml:"user" validate:"required" reload:"restart"`
+	Pass    string `yaml:"pass" secret:"true" reload:"restart"`
+	Name    string `yaml:"name" validate:"required" reload:"restart"`
+	Debug   bool   `yaml:"debug" reload:"restart"`
+	SSLMode string `yaml:"ssl_mode" default:"disable" validate:"oneof=disable allow prefer require verify-ca verify-full" reload:"restart"`
+	Migrate bool   `yaml:"migrate" reload:"restart"`
 }
 
 type Rest struct {
-	Host string `yaml:"host"`
-	Port string `yaml:"port"`
+	Host string `yaml:"host" default:"0.0.0.0" reload:"restart"`
+	Port string `yaml:"port" default:"4041" validate:"required,numeric" reload:"restart"`
+
+	// RateLimit is the default number of requests per client in 10 minutes on rate limited routes
+	// 0 turns the default limit off, routes with their own limit keep it
+	RateLimit int `yaml:"rate_limit" default:"100" validate:"gte=0"`
+
+	// TrustedProxies are the IPs or CIDRs allowed to set forwarded client IP headers
+	// The local networks and Cloudflare ranges are trusted when empty
+	TrustedProxies []string `yaml:"trusted_proxies" validate:"dive,cidr|ip"`
+
+	AccessLog AccessLog `yaml:"access_log"`
+
+	// ProblemJSON answers errors as RFC 9457 application/problem+json instead of {message, code}
+	ProblemJSON bool `yaml:"problem_json"`
+
+	// ProblemTypeBase is prefixed to the result code to build the problem type URI, about:blank when empty
+	// example: https://docs.example.com/errors/ gives https://docs.example.com/errors/1002
+	ProblemTypeBase string `yaml:"problem_type_base" validate:"omitempty,url"`
+
+	OpenAPI OpenAPI `yaml:"openapi"`
+}
+
+type OpenAPI struct {
+	// Enabled serves the OpenAPI document generated from the registered routes at /openapi.json
+	Enabled bool `yaml:"enabled" reload:"restart"`
+
+	// Docs serves an API reference UI reading the document at /docs, it also serves the document
+	Docs bool `yaml:"docs" reload:"restart"`
+
+	Title   string `yaml:"title" default:"idiogo API" reload:"restart"`
+	Version string `yaml:"version" default:"1.0.0" reload:"restart"`
+}
+
+type AccessLog struct {
+	// Enabled logs every request of the server, modules can still opt in per group when disabled
+	Enabled bool `yaml:"enabled"`
+
+	// SampleRate is the share of requests logged, server errors are always logged
+	SampleRate float64 `yaml:"sample_rate" default:"1" validate:"gt=0,lte=1"`
+
+	// Exclude lists the paths never logged, a trailing * matches a prefix
+	Exclude []string `yaml:"exclude" default:"/healthz,/readyz,/health"`
+}
+
+type Log struct {
+	Level  string `yaml:"level" default:"info" validate:"oneof=debug info warn error"`
+	Format string `yaml:"format" default:"text" validate:"oneof=json text" reload:"restart"`
+}
+
+type Metrics struct {
+	// Enabled starts the admin listener serving the metrics apart from the REST API
+	Enabled bool   `yaml:"enabled" reload:"restart"`
+	Host    string `yaml:"host" default:"0.0.0.0" reload:"restart"`
+	Port    string `yaml:"port" default:"9090" validate:"required,numeric" reload:"restart"`
+	Path    string `yaml:"path" default:"/metrics" validate:"startswith=/" reload:"restart"`
+}
+
+type Grpc struct {
+	// Enabled starts the gRPC listener next to the REST API
+	Enabled bool   `yaml:"enabled" reload:"restart"`
+	Host    string `yaml:"host" default:"0.0.0.0" reload:"restart"`
+	Port    string `yaml:"port" default:"9091" validate:"required,numeric" reload:"restart"`
+
+	// Reflection registers the server reflection service used by tools like grpcurl
+	Reflection bool `yaml:"reflection" reload:"restart"`
+}
+
+type Pagination struct {
+	// MaxLimit is the largest page size served, larger limits are lowered to it
+	MaxLimit int `yaml:"max_limit" default:"100" validate:"gt=0"`
+
+	// CursorSecret signs the page cursors, every instance serving the same clients needs the same secret
+	// A random secret is used when empty, so cursors stop working on restart
+	CursorSecret string `yaml:"cursor_secret" secret:"true"`
+}
+
+type Tracing struct {
+	// Enabled records spans for requests, service calls and queries
+	Enabled bool `yaml:"enabled" reload:"restart"`
+
+	// Exporter is stdout or file, both write one OTLP/JSON ExportTraceServiceRequest line per span
+	Exporter string `yaml:"exporter" default:"stdout" validate:"oneof=stdout file" reload:"restart"`
+
+	// File is the path the file exporter appends to
+	File string `yaml:"file" default:"./traces.jsonl" validate:"required_if=Exporter file" reload:"restart"`
+
+	// SampleRate is the share of new traces recorded, incoming traceparent headers keep their decision
+	SampleRate float64 `yaml:"sample_rate" default:"1" validate:"gt=0,lte=1" reload:"restart"`
+
+	Service string `yaml:"service" default:"idiogo" reload:"restart"`
+}
+
+type Shutdown struct {
+	// Drain is how long the server keeps serving after readiness starts failing, 0s stops right away
+	Drain time.Duration `yaml:"drain" default:"5s" validate:"gte=0" reload:"restart"`
+
+	// StepTimeout bounds stopping each server and dependency
+	StepTimeout time.Duration `yaml:"step_timeout" default:"10s" validate:"gt=0" reload:"restart"`
 }
 
 type Config struct {
-	DB   Database `yaml:"db"`
-	I18n I18n     `yaml:"i18n"`
-	Rest Rest     `yaml:"rest"`
+	DB         Database   `yaml:"db"`
+	I18n       I18n       `yaml:"i18n"`
+	Rest       Rest       `yaml:"rest"`
+	Log        Log        `yaml:"log"`
+	Grpc       Grpc       `yaml:"grpc"`
+	Pagination Pagination `yaml:"pagination"`
+	Metrics    Metrics    `yaml:"metrics"`
+	Tracing    Tracing    `yaml:"tracing"`
+	Shutdown   Shutdown   `yaml:"shutdown"`
 }
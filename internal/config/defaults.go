@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ApplyDefaults fills every zero field of the struct v points to with its `default:"..."` tag
+// Slices are given as comma separated values, e.g. `default:"en,tr"`
+// It returns the yaml paths of the fields that were set
+func ApplyDefaults(v interface{}) ([]string, error) {
+	return applyDefaultsExcept(v, nil)
+}
+
+// applyDefaultsExcept is ApplyDefaults leaving the yaml paths in explicit as they are
+// so a value given as zero, like shutdown.drain: 0s, is kept
+func applyDefaultsExcept(v interface{}, explicit map[string]bool) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: defaults need a pointer to a struct, got %T", v)
+	}
+	return applyDefaults(rv.Elem(), nil, explicit)
+}
+
+func applyDefaults(rv reflect.Value, path []string, explicit map[string]bool) ([]string, error) {
+	var set []string
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		p := append(append([]string{}, path...), name)
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Struct {
+			nested, err := applyDefaults(fv, p, explicit)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, nested...)
+			continue
+		}
+		def, ok := sf.Tag.Lookup("default")
+		key := strings.Join(p, ".")
+		if !ok || !fv.IsZero() || explicit[key] {
+			continue
+		}
+		if err := setDefault(fv, def); err != nil {
+			return nil, fmt.Errorf("config %s: invalid default %q: %w", key, def, err)
+		}
+		set = append(set, key)
+	}
+	return set, nil
+}
+
+func setDefault(fv reflect.Value, def string) error {
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(def)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(def)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(def)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(def, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(def, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(def, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", fv.Type())
+		}
+		parts := strings.Split(def, ",")
+		list := reflect.MakeSlice(fv.Type(), 0, len(parts))
+		for _, part := range parts {
+			list = reflect.Append(list, reflect.ValueOf(strings.TrimSpace(part)).Convert(fv.Type().Elem()))
+		}
+		fv.Set(list)
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	type nested struct {
+		Timeout time.Duration `yaml:"timeout" default:"1m30s"`
+	}
+	type target struct {
+		Name    string        `yaml:"name" default:"idiogo"`
+		Enabled bool          `yaml:"enabled" default:"true"`
+		Limit   int           `yaml:"limit" default:"10"`
+		Size    uint8         `yaml:"size" default:"8"`
+		Rate    float64       `yaml:"rate" default:"0.5"`
+		Tags    []string      `yaml:"tags" default:"a, b"`
+		Drain   time.Duration `yaml:"drain" default:"5s"`
+		Nested  nested        `yaml:"nested"`
+		Kept    string        `yaml:"kept" default:"default"`
+		NoTag   string        `yaml:"no_tag"`
+		Skipped string        `yaml:"-" default:"x"`
+		Plain   int           `default:"3"`
+	}
+	v := target{Kept: "set"}
+	set, err := ApplyDefaults(&v)
+	if err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+	want := target{
+		Name:    "idiogo",
+		Enabled: true,
+		Limit:   10,
+		Size:    8,
+		Rate:    0.5,
+		Tags:    []string{"a", "b"},
+		Drain:   5 * time.Second,
+		Nested:  nested{Timeout: 90 * time.Second},
+		Kept:    "set",
+		Plain:   3,
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("ApplyDefaults() = %+v, want %+v", v, want)
+	}
+	wantSet := []string{"name", "enabled", "limit", "size", "rate", "tags", "drain", "nested.timeout", "plain"}
+	if !reflect.DeepEqual(set, wantSet) {
+		t.Errorf("ApplyDefaults() set = %v, want %v", set, wantSet)
+	}
+}
+
+func TestApplyDefaultsConfig(t *testing.T) {
+	var cnf Config
+	if _, err := ApplyDefaults(&cnf); err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+	if cnf.Shutdown.Drain != 5*time.Second || cnf.Shutdown.StepTimeout != 10*time.Second {
+		t.Errorf("shutdown = %+v, want 5s and 10s", cnf.Shutdown)
+	}
+	if want := []string{"/healthz", "/readyz", "/health"}; !reflect.DeepEqual(cnf.Rest.AccessLog.Exclude, want) {
+		t.Errorf("rest.access_log.exclude = %v, want %v", cnf.Rest.AccessLog.Exclude, want)
+	}
+	if cnf.DB.Port != "5432" || cnf.Rest.RateLimit != 100 || cnf.Tracing.SampleRate != 1 {
+		t.Errorf("defaults not applied: db.port=%q rest.rate_limit=%d tracing.sample_rate=%v",
+			cnf.DB.Port, cnf.Rest.RateLimit, cnf.Tracing.SampleRate)
+	}
+}
+
+func TestApplyDefaultsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		err  string
+	}{
+		{"not a pointer", Config{}, "need a pointer to a struct"},
+		{"pointer to non struct", new(int), "need a pointer to a struct"},
+		{"bad duration", &struct {
+			D time.Duration `yaml:"d" default:"5"`
+		}{}, `config d: invalid default "5"`},
+		{"bad int", &struct {
+			N struct {
+				N int `yaml:"n" default:"x"`
+			} `yaml:"outer"`
+		}{}, `config outer.n: invalid default "x"`},
+		{"bad bool", &struct {
+			B bool `yaml:"b" default:"yes please"`
+		}{}, `config b: invalid default`},
+		{"unsupported slice", &struct {
+			S []int `yaml:"s" default:"1,2"`
+		}{}, "unsupported slice type []int"},
+		{"unsupported type", &struct {
+			M map[string]string `yaml:"m" default:"a"`
+		}{}, "unsupported type map[string]string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyDefaults(tt.v)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("ApplyDefaults() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoadKeepsExplicitZero(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		env    map[string]string
+		drain  time.Duration
+		limit  int
+		source string
+	}{
+		{name: "unset", yaml: "db:\n  host: a\n", drain: 5 * time.Second, limit: 100, source: LayerDefault},
+		{name: "null", yaml: "shutdown:\n  drain:\nrest:\n  rate_limit:\n", drain: 5 * time.Second, limit: 100, source: LayerDefault},
+		{name: "file zero", yaml: "shutdown:\n  drain: 0s\nrest:\n  rate_limit: 0\n", source: LayerFile},
+		{name: "env zero", yaml: "db:\n  host: a\n", env: map[string]string{
+			"IDIOGO_SHUTDOWN_DRAIN":  "0s",
+			"IDIOGO_REST_RATE_LIMIT": "0",
+		}, source: LayerEnv},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(writeFiles(t, map[string]string{"config.yaml": tt.yaml}), "config.yaml")
+			var cnf Config
+			sources, err := Loader{Path: path, LookupEnv: lookup(tt.env)}.Load(&cnf)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cnf.Shutdown.Drain != tt.drain {
+				t.Errorf("shutdown.drain = %v, want %v", cnf.Shutdown.Drain, tt.drain)
+			}
+			if cnf.Rest.RateLimit != tt.limit {
+				t.Errorf("rest.rate_limit = %d, want %d", cnf.Rest.RateLimit, tt.limit)
+			}
+			if got := sources["shutdown.drain"].Layer; got != tt.source {
+				t.Errorf("source of shutdown.drain = %s, want %s", got, tt.source)
+			}
+		})
+	}
+}
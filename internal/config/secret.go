@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MaskSecrets replaces every non empty string field tagged `secret:"true"` of the struct v points to with mask
+func MaskSecrets(v interface{}, mask string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: masking secrets needs a pointer to a struct, got %T", v)
+	}
+	maskSecrets(rv.Elem(), mask)
+	return nil
+}
+
+func maskSecrets(rv reflect.Value, mask string) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Struct {
+			maskSecrets(fv, mask)
+			continue
+		}
+		if sf.Tag.Get("secret") == "true" && fv.Kind() == reflect.String && fv.String() != "" {
+			fv.SetString(mask)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+func TestMaskSecrets(t *testing.T) {
+	cnf := Config{
+		DB:         Database{Host: "localhost", Pass: "postgres"},
+		Pagination: Pagination{CursorSecret: "s3cret"},
+	}
+	if err := MaskSecrets(&cnf, "***"); err != nil {
+		t.Fatalf("MaskSecrets() error = %v", err)
+	}
+	if cnf.DB.Pass != "***" || cnf.Pagination.CursorSecret != "***" {
+		t.Errorf("secrets = %q, %q, want them masked", cnf.DB.Pass, cnf.Pagination.CursorSecret)
+	}
+	if cnf.DB.Host != "localhost" {
+		t.Errorf("DB.Host = %q, want it kept", cnf.DB.Host)
+	}
+
+	empty := Config{}
+	if err := MaskSecrets(&empty, "***"); err != nil {
+		t.Fatalf("MaskSecrets() error = %v", err)
+	}
+	if empty.DB.Pass != "" {
+		t.Errorf("empty DB.Pass = %q, want it kept empty", empty.DB.Pass)
+	}
+	if err := MaskSecrets(cnf, "***"); err == nil {
+		t.Error("MaskSecrets() of a struct value succeeded, want an error")
+	}
+}
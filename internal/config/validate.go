@@ -0,0 +1,41 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/restayway/rescode"
+	"github.com/salihguru/idiogo/pkg/validation"
+)
+
+// ValidationError lists every invalid config field
+type ValidationError struct {
+	Fields []*validation.ErrorResponse
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid config:")
+	for _, f := range e.Fields {
+		fmt.Fprintf(&b, "\n  - %s: %s (value: %v)", f.Namespace, f.Message, f.Value)
+	}
+	return b.String()
+}
+
+// Validate checks the config against its `validate` tags
+// Every invalid field is reported at once in a *ValidationError
+func Validate(ctx context.Context, srv *validation.Srv, cnf Config) error {
+	err := srv.ValidateStruct(ctx, cnf)
+	if err == nil {
+		return nil
+	}
+	var rc *rescode.RC
+	if errors.As(err, &rc) {
+		if fields, ok := rc.Data.([]*validation.ErrorResponse); ok {
+			return &ValidationError{Fields: fields}
+		}
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/salihguru/idiogo/pkg/validation"
+)
+
+// valid returns a config passing validation
+func valid() Config {
+	cnf := Config{DB: Database{Host: "localhost", User: "postgres", Name: "idiogo"}}
+	if _, err := ApplyDefaults(&cnf); err != nil {
+		panic(err)
+	}
+	return cnf
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		fields []string
+		lines  []string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{
+			name:   "missing db host",
+			modify: func(c *Config) { c.DB.Host = "" },
+			fields: []string{"DB.Host"},
+			lines:  []string{"  - DB.Host: "},
+		},
+		{
+			name: "every invalid field",
+			modify: func(c *Config) {
+				c.DB.Port = "abc"
+				c.DB.SSLMode = "maybe"
+				c.Log.Level = "trace"
+				c.I18n.Default = "de"
+				c.Rest.TrustedProxies = []string{"10.0.0.0/8", "nope"}
+				c.Shutdown.StepTimeout = 0
+			},
+			fields: []string{
+				"DB.Port", "DB.SSLMode", "I18n.Default",
+				"Rest.TrustedProxies[1]", "Log.Level", "Shutdown.StepTimeout",
+			},
+			lines: []string{"(value: abc)", "(value: maybe)", "(value: nope)"},
+		},
+		{
+			name: "file exporter needs a file",
+			modify: func(c *Config) {
+				c.Tracing.Exporter = "file"
+				c.Tracing.File = ""
+			},
+			fields: []string{"Tracing.File"},
+		},
+	}
+	srv := validation.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cnf := valid()
+			tt.modify(&cnf)
+			err := Validate(context.Background(), srv, cnf)
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+			got := make(map[string]bool)
+			for _, f := range verr.Fields {
+				got[f.Namespace] = true
+				if f.Message == "" {
+					t.Errorf("%s has no message", f.Namespace)
+				}
+			}
+			for _, f := range tt.fields {
+				if !got[f] {
+					t.Errorf("%s is not reported in %v", f, err)
+				}
+			}
+			if len(verr.Fields) != len(tt.fields) {
+				t.Errorf("%d fields reported, want %d: %v", len(verr.Fields), len(tt.fields), err)
+			}
+			msg := err.Error()
+			if !strings.HasPrefix(msg, "invalid config:\n") {
+				t.Errorf("Error() = %q", msg)
+			}
+			for _, line := range tt.lines {
+				if !strings.Contains(msg, line) {
+					t.Errorf("Error() = %q, want it to contain %q", msg, line)
+				}
+			}
+		})
+	}
+}
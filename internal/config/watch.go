@@ -0,0 +1,187 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Change is published to subscribers when the config is reloaded
+type Change struct {
+	// Old is the previously published config
+	Old Config
+
+	// New is the published config, fields requiring restart keep their running values
+	New Config
+
+	// Changed lists the yaml paths of the applied changes
+	Changed []string
+
+	// Restart lists the yaml paths that changed on disk but need a restart to apply
+	Restart []string
+}
+
+// Subscriber is called with every published change
+// It must not modify the configs it receives
+type Subscriber func(Change)
+
+// ValidateFunc checks a reloaded config before it is published
+type ValidateFunc func(Config) error
+
+// Watcher reloads the config when its files change and publishes immutable snapshots
+type Watcher struct {
+	loader   Loader
+	validate ValidateFunc
+
+	mu      sync.Mutex
+	current Config
+	subs    []Subscriber
+	mtimes  map[string]time.Time
+}
+
+// NewWatcher creates a watcher publishing reloads of loader over the running config
+func NewWatcher(loader Loader, running Config, validate ValidateFunc) *Watcher {
+	w := &Watcher{
+		loader:   loader,
+		validate: validate,
+		current:  clone(running),
+	}
+	w.mtimes = w.stat()
+	return w
+}
+
+// Current returns a copy of the published config
+func (w *Watcher) Current() Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return clone(w.current)
+}
+
+// Subscribe registers fn to be called on every published change
+func (w *Watcher) Subscribe(fn Subscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs = append(w.subs, fn)
+}
+
+// Reload re-reads and validates the config and publishes it if anything changed
+// The running config is kept when the new one is invalid
+func (w *Watcher) Reload() error {
+	var next Config
+	if _, err := w.loader.Load(&next); err != nil {
+		return err
+	}
+	if w.validate != nil {
+		if err := w.validate(next); err != nil {
+			return err
+		}
+	}
+
+	w.mu.Lock()
+	old := w.current
+	var changed, restart []string
+	diff(reflect.ValueOf(&next).Elem(), reflect.ValueOf(old), nil, &changed, &restart)
+	if len(changed) == 0 && len(restart) == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	w.current = clone(next)
+	subs := append([]Subscriber{}, w.subs...)
+	w.mu.Unlock()
+
+	change := Change{Old: clone(old), New: clone(next), Changed: changed, Restart: restart}
+	for _, fn := range subs {
+		fn(change)
+	}
+	return nil
+}
+
+// Watch polls the config files every interval and reloads on change until ctx is done
+func (w *Watcher) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			mtimes := w.stat()
+			if reflect.DeepEqual(mtimes, w.mtimes) {
+				continue
+			}
+			w.mtimes = mtimes
+			if err := w.Reload(); err != nil {
+				slog.Error("config reload failed, keeping the running config", "error", err)
+			}
+		}
+	}
+}
+
+func (w *Watcher) stat() map[string]time.Time {
+	mtimes := make(map[string]time.Time)
+	for _, f := range w.loader.Files() {
+		if info, err := os.Stat(f); err == nil {
+			mtimes[f] = info.ModTime()
+		}
+	}
+	return mtimes
+}
+
+// diff compares next against old by yaml path
+// Fields tagged reload:"restart" are reset to their old value in next and reported in restart
+func diff(next, old reflect.Value, path []string, changed, restart *[]string) {
+	t := next.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		p := append(append([]string{}, path...), name)
+		nf, of := next.Field(i), old.Field(i)
+		if nf.Kind() == reflect.Struct {
+			diff(nf, of, p, changed, restart)
+			continue
+		}
+		if reflect.DeepEqual(nf.Interface(), of.Interface()) {
+			continue
+		}
+		if sf.Tag.Get("reload") == "restart" {
+			nf.Set(of)
+			*restart = append(*restart, strings.Join(p, "."))
+			continue
+		}
+		*changed = append(*changed, strings.Join(p, "."))
+	}
+}
+
+// clone deep copies the slices of c so published snapshots never share memory
+func clone(c Config) Config {
+	out := c
+	cloneSlices(reflect.ValueOf(&out).Elem())
+	return out
+}
+
+func cloneSlices(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Struct:
+			cloneSlices(f)
+		case reflect.Slice:
+			if f.IsNil() || !f.CanSet() {
+				continue
+			}
+			cp := reflect.MakeSlice(f.Type(), f.Len(), f.Len())
+			reflect.Copy(cp, f)
+			f.Set(cp)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		changed []string
+		restart []string
+	}{
+		{name: "unchanged", modify: func(*Config) {}},
+		{
+			name:    "reloadable",
+			modify:  func(c *Config) { c.Log.Level = "debug"; c.Rest.RateLimit = 10 },
+			changed: []string{"rest.rate_limit", "log.level"},
+		},
+		{
+			name:    "restart",
+			modify:  func(c *Config) { c.DB.Host = "other"; c.Shutdown.Drain = 0 },
+			restart: []string{"db.host", "shutdown.drain"},
+		},
+		{
+			name: "both",
+			modify: func(c *Config) {
+				c.I18n.Locales = []string{"en", "tr"}
+				c.I18n.Dir = "./other"
+				c.Rest.AccessLog.Exclude = nil
+				c.Rest.OpenAPI.Enabled = true
+			},
+			changed: []string{"i18n.locales", "rest.access_log.exclude"},
+			restart: []string{"i18n.dir", "rest.openapi.enabled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := valid()
+			next := clone(old)
+			tt.modify(&next)
+			var changed, restart []string
+			diff(reflect.ValueOf(&next).Elem(), reflect.ValueOf(old), nil, &changed, &restart)
+			if !reflect.DeepEqual(changed, tt.changed) {
+				t.Errorf("changed = %v, want %v", changed, tt.changed)
+			}
+			if !reflect.DeepEqual(restart, tt.restart) {
+				t.Errorf("restart = %v, want %v", restart, tt.restart)
+			}
+			if next.DB.Host != old.DB.Host || next.I18n.Dir != old.I18n.Dir || next.Shutdown.Drain != old.Shutdown.Drain {
+				t.Error("restart fields were applied")
+			}
+		})
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := "db:\n  host: localhost\n  user: postgres\n  name: idiogo\n"
+	write(base)
+	loader := Loader{Path: path, LookupEnv: lookup(nil)}
+	var running Config
+	if _, err := loader.Load(&running); err != nil {
+		t.Fatal(err)
+	}
+	invalid := errors.New("invalid")
+	w := NewWatcher(loader, running, func(c Config) error {
+		if c.Log.Level == "trace" {
+			return invalid
+		}
+		return nil
+	})
+	var changes []Change
+	w.Subscribe(func(c Change) { changes = append(changes, c) })
+
+	if err := w.Reload(); err != nil || len(changes) != 0 {
+		t.Fatalf("Reload() of the same config = %v, %d changes", err, len(changes))
+	}
+
+	write(base + "log:\n  level: trace\n")
+	if err := w.Reload(); !errors.Is(err, invalid) {
+		t.Fatalf("Reload() error = %v, want %v", err, invalid)
+	}
+	if len(changes) != 0 || w.Current().Log.Level != "info" {
+		t.Fatal("an invalid config was published")
+	}
+
+	write("db:\n  host: other\n  user: postgres\n  name: idiogo\nlog:\n  level: debug\n")
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("%d changes published, want 1", len(changes))
+	}
+	c := changes[0]
+	if !reflect.DeepEqual(c.Changed, []string{"log.level"}) || !reflect.DeepEqual(c.Restart, []string{"db.host"}) {
+		t.Errorf("changed = %v, restart = %v", c.Changed, c.Restart)
+	}
+	if c.Old.Log.Level != "info" || c.New.Log.Level != "debug" || c.New.DB.Host != "localhost" {
+		t.Errorf("change = %+v", c)
+	}
+	if cur := w.Current(); cur.Log.Level != "debug" || cur.DB.Host != "localhost" {
+		t.Errorf("Current() = %+v", cur)
+	}
+}
+
+func TestClone(t *testing.T) {
+	c := valid()
+	c.I18n.Locales = []string{"en"}
+	cp := clone(c)
+	cp.I18n.Locales[0] = "tr"
+	if c.I18n.Locales[0] != "en" {
+		t.Error("clone shares slices")
+	}
+}
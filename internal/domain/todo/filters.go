@@ -1,6 +1,37 @@
 package todo
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salihguru/idiogo/pkg/query"
+)
+
 type Filters struct {
 	Status string `query:"status"`
 	Q      string `query:"q"`
+	query.FilterReq
+}
+
+// filterSpec allows the filter[field][op] parameters of the todo lists
+var filterSpec = query.NewFilterSpec(
+	query.FilterOn[uuid.UUID]("id", query.OpEq, query.OpIn),
+	query.FilterOn[Status]("status", query.OpEq, query.OpNe, query.OpIn, query.OpNin).
+		Values(string(StatusPending), string(StatusCompleted), string(StatusCancelled), string(StatusArchived)),
+	query.FilterOn[string]("title", query.OpEq, query.OpILike),
+	query.FilterOn[string]("description", query.OpILike),
+	query.FilterOn[time.Time]("created_at", query.OpGt, query.OpGte, query.OpLt, query.OpLte),
+	query.FilterOn[time.Time]("updated_at", query.OpGt, query.OpGte, query.OpLt, query.OpLte),
+)
+
+// sortSpec allows the sort parameter of the todo lists, newest first by default
+var sortSpec = query.NewSortSpec("-created_at",
+	query.SortOn("title"),
+	query.SortOn("status"),
+	query.SortOn("created_at"),
+	query.SortOn("updated_at").Nulls(query.NullsLast),
+)
+
+func (Filters) FilterSpec() *query.FilterSpec {
+	return filterSpec
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/salihguru/idiogo/internal/port"
 	"github.com/salihguru/idiogo/internal/rest"
+	"github.com/salihguru/idiogo/pkg/list"
 )
 
 type Handler struct {
@@ -17,17 +18,10 @@ func NewHandler(srv Service) *Handler {
 func (h *Handler) RegisterRoutes(srv port.RestService, router fiber.Router) {
 	group := router.Group("/todos")
 
-	group.Post("/",
-		srv.Timeout(rest.Handle(rest.WithBody(rest.WithValidation(srv.ValidateStruct(), rest.CreateResponds(h.srv.Create))))))
-
-	group.Get("/",
-		srv.Timeout(rest.Handle(rest.WithQuery(rest.Data(h.srv.Find)))))
-	group.Get("/:id",
-		srv.Timeout(rest.Handle(rest.WithParams(rest.WithValidation(srv.ValidateStruct(), rest.Data(h.srv.View))))))
-
-	group.Patch("/:id",
-		srv.Timeout(rest.Handle(rest.WithParams(rest.WithBody(rest.WithValidation(srv.ValidateStruct(), rest.Data(h.srv.Update)))))))
-
-	group.Delete("/:id",
-		srv.Timeout(rest.Handle(rest.WithParams(rest.WithValidation(srv.ValidateStruct(), rest.Void(h.srv.Delete))))))
+	rest.Route[CreateReq, *Todo](srv, group).Post("/").Body().Handle(h.srv.Create)
+	rest.Route[ListReq, *list.Page[*Todo]](srv, group).Get("/").Query().Handle(h.srv.Find)
+	rest.Route[CursorListReq, *list.CursorPage[*Todo]](srv, group).Get("/cursor").Query().Handle(h.srv.FindByCursor)
+	rest.Route[ViewReq, *Todo](srv, group).Get("/:id").Params().Handle(h.srv.View)
+	rest.Route[UpdateReq, *Todo](srv, group).Patch("/:id").Params().Body().Handle(h.srv.Update)
+	rest.Route[DeleteReq, any](srv, group).Delete("/:id").Params().Exec(h.srv.Delete)
 }
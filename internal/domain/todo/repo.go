@@ -7,6 +7,7 @@ import (
 	"github.com/salihguru/idiogo/pkg/list"
 	"github.com/salihguru/idiogo/pkg/query"
 	"github.com/salihguru/idiogo/pkg/xrepo"
+	"github.com/salihguru/idiogo/pkg/xrescode"
 	"gorm.io/gorm"
 )
 
@@ -23,19 +24,51 @@ func (r *Repo) Save(ctx context.Context, todo *Todo) error {
 }
 
 func (r *Repo) View(ctx context.Context, id uuid.UUID) (*Todo, error) {
-	return xrepo.ViewByID[Todo](ctx, r.db, id)
+	todo, err := xrepo.ViewByID[Todo](ctx, r.db, id)
+	if xrescode.Is(err, xrescode.NotFoundCode) {
+		return nil, NotFound(err)
+	}
+	return todo, err
+}
+
+// Find lists the todos in the order of sort, see sortSpec
+func (r *Repo) Find(ctx context.Context, f Filters, sort string, pagi list.PagiRequest) (*list.Page[*Todo], error) {
+	conds, err := r.conds(f)
+	if err != nil {
+		return nil, err
+	}
+	order, err := sortSpec.Conds(sort)
+	if err != nil {
+		return nil, xrescode.ValidationFailed(err)
+	}
+	return xrepo.FindPaged[*Todo](ctx, r.db, pagi, query.OrderBy(order), query.Apply(conds))
 }
 
-func (r *Repo) Find(ctx context.Context, f Filters, pagi list.PagiRequest) ([]*Todo, error) {
-	return xrepo.Find[*Todo](ctx, r.db,
-		query.Apply(r.conds(f)),
-		list.Paginate(&pagi),
-	)
+// FindByCursor lists the newest todos first, see xrepo.FindByCursor
+func (r *Repo) FindByCursor(ctx context.Context, f Filters, req list.CursorRequest) (*list.CursorPage[*Todo], error) {
+	conds, err := r.conds(f)
+	if err != nil {
+		return nil, err
+	}
+	return xrepo.FindByCursor[*Todo](ctx, r.db, req, r.sorts(), query.Apply(conds))
 }
 
-func (r *Repo) conds(f Filters) []query.Conds {
-	return []query.Conds{
+// sorts is the order of the cursor pages, served by the idx_todos_created_at_id index
+func (r *Repo) sorts() []query.SortCond {
+	return []query.SortCond{
+		query.SortBasic("created_at", query.SortDesc, false),
+		query.SortBasic("id", query.SortDesc, false),
+	}
+}
+
+// conds combines the status and q parameters with the filters, the filters are already validated by the handlers
+func (r *Repo) conds(f Filters) ([]query.Conds, error) {
+	conds, err := filterSpec.Conds(f.Filter)
+	if err != nil {
+		return nil, xrescode.ValidationFailed(err)
+	}
+	return append(conds,
 		query.ILike("title", f.Q),
 		query.Eq("status", f.Status, f.Status == ""),
-	}
+	), nil
 }
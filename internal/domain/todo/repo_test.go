@@ -0,0 +1,46 @@
+package todo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/restayway/stx"
+	"github.com/salihguru/idiogo/pkg/list"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestRepoFindCountIsNotOrdered(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a dry run db can't begin a transaction, so it is given as the current one
+	ctx := stx.New(context.Background(), db)
+	for _, sort := range []string{"", "-title,updated_at"} {
+		queries = nil
+		if _, err := NewRepo(db).Find(ctx, Filters{Q: "milk"}, sort, list.PagiRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		// a dry run counts nothing, so the page isn't read
+		if len(queries) != 1 || !strings.HasPrefix(queries[0], "SELECT count(*)") {
+			t.Fatalf("queries of sort %q = %q, want the count only", sort, queries)
+		}
+		if strings.Contains(queries[0], "ORDER BY") {
+			t.Errorf("count of sort %q = %s, want it without ORDER BY", sort, queries[0])
+		}
+	}
+}
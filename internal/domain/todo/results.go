@@ -0,0 +1,15 @@
+//go:generate go run github.com/restayway/rescode/cmd/rescodegen --input result.yml --output result_gen.go --package todo
+package todo
+
+import (
+	_ "embed"
+
+	"github.com/salihguru/idiogo/pkg/xrescode"
+)
+
+//go:embed result.yml
+var results []byte
+
+func init() {
+	xrescode.RegisterYAML("todo", results)
+}
@@ -6,14 +6,20 @@ import (
 	"github.com/google/uuid"
 	"github.com/salihguru/idiogo/pkg/entity"
 	"github.com/salihguru/idiogo/pkg/list"
+	"github.com/salihguru/idiogo/pkg/metrics"
+	"github.com/salihguru/idiogo/pkg/query"
 )
 
 type Service struct {
-	repo *Repo
+	repo    *Repo
+	created *metrics.Counter
 }
 
-func NewService(repo *Repo) *Service {
-	return &Service{repo: repo}
+func NewService(repo *Repo, reg *metrics.Registry) *Service {
+	return &Service{
+		repo:    repo,
+		created: reg.Counter("todos_created_total", "Number of todos created.", "status"),
+	}
 }
 
 type CreateReq struct {
@@ -22,19 +28,33 @@ type CreateReq struct {
 }
 
 type UpdateReq struct {
-	ID          uuid.UUID `params:"id" validate:"required,uuid"`
+	ID          uuid.UUID `params:"id" json:"-" validate:"required,uuid"`
 	Title       *string   `json:"title" validate:"omitempty,min=3,max=255"`
 	Description *string   `json:"description" validate:"omitempty,max=5000"`
 	Status      *string   `json:"status" validate:"omitempty,oneof=pending completed cancelled archived"`
 }
 
 type ViewReq struct {
-	ID uuid.UUID `params:"id" validate:"required,uuid"`
+	ID uuid.UUID `params:"id" json:"-" validate:"required,uuid"`
+}
+
+type DeleteReq struct {
+	ID uuid.UUID `params:"id" json:"-" validate:"required,uuid"`
 }
 
 type ListReq struct {
 	Filters
 	list.PagiRequest
+	query.SortReq
+}
+
+func (ListReq) SortSpec() *query.SortSpec {
+	return sortSpec
+}
+
+type CursorListReq struct {
+	Filters
+	list.CursorRequest
 }
 
 func (s *Service) Create(ctx context.Context, req CreateReq) (*Todo, error) {
@@ -46,6 +66,7 @@ func (s *Service) Create(ctx context.Context, req CreateReq) (*Todo, error) {
 	if err := s.repo.Save(ctx, todo); err != nil {
 		return nil, err
 	}
+	s.created.Inc(string(todo.Status))
 	return todo, nil
 }
 
@@ -73,12 +94,16 @@ func (s *Service) View(ctx context.Context, req ViewReq) (*Todo, error) {
 	return s.repo.View(ctx, req.ID)
 }
 
-func (s *Service) Find(ctx context.Context, req ListReq) ([]*Todo, error) {
-	return s.repo.Find(ctx, req.Filters, req.PagiRequest)
+func (s *Service) Find(ctx context.Context, req ListReq) (*list.Page[*Todo], error) {
+	return s.repo.Find(ctx, req.Filters, req.Sort, req.PagiRequest)
 }
 
-func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
-	todo, err := s.repo.View(ctx, id)
+func (s *Service) FindByCursor(ctx context.Context, req CursorListReq) (*list.CursorPage[*Todo], error) {
+	return s.repo.FindByCursor(ctx, req.Filters, req.CursorRequest)
+}
+
+func (s *Service) Delete(ctx context.Context, req DeleteReq) error {
+	todo, err := s.repo.View(ctx, req.ID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/restayway/rescode"
+	"github.com/salihguru/idiogo/pkg/state"
+	"github.com/salihguru/idiogo/pkg/validation"
+	"github.com/salihguru/idiogo/pkg/xlog"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the ErrorInfo domain of every result code
+const errorDomain = "idiogo"
+
+// toStatus answers result codes with their gRPC code and translated message
+// The result code is sent as an ErrorInfo reason and validation errors as BadRequest field violations
+// Statuses pass through and any other error becomes the failed result code
+func (s *Server) toStatus(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	err = xrescode.Classify(err)
+	var res *rescode.RC
+	if !errors.As(err, &res) {
+		res = xrescode.Failed(err)
+	}
+	if res.HttpCode >= http.StatusInternalServerError {
+		xlog.From(ctx).ErrorContext(ctx, "call failed", "error", err)
+	}
+	st := status.New(res.RpcCode, s.cnf.I18n.Translate(res.Message, state.LocaleStr(ctx)))
+	info := &errdetails.ErrorInfo{
+		Reason:   strconv.FormatUint(res.Code, 10),
+		Domain:   errorDomain,
+		Metadata: map[string]string{"request_id": state.RequestID(ctx)},
+	}
+	detailed, dErr := st.WithDetails(info)
+	if br := badRequest(res.Data); br != nil {
+		detailed, dErr = st.WithDetails(info, br)
+	}
+	if dErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// badRequest lists the validation errors of data as field violations, nil for any other data
+func badRequest(data any) *errdetails.BadRequest {
+	errs, ok := data.([]*validation.ErrorResponse)
+	if !ok || len(errs) == 0 {
+		return nil
+	}
+	br := &errdetails.BadRequest{}
+	for _, e := range errs {
+		field := e.Namespace
+		if field == "" {
+			field = e.Field
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: e.Message,
+		})
+	}
+	return br
+}
@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"slices"
+	"sync/atomic"
+
+	"github.com/salihguru/idiogo/internal/config"
+	"github.com/salihguru/idiogo/internal/domain/todo"
+	todov1 "github.com/salihguru/idiogo/internal/grpc/proto/todo/v1"
+	"github.com/salihguru/idiogo/internal/rest"
+	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/validation"
+	"github.com/salihguru/idiogo/pkg/xip"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Settings are the gRPC options that can change while the server is running
+type Settings struct {
+	Locales        []string
+	DefaultLocale  string
+	TrustedProxies xip.Networks
+}
+
+type Config struct {
+	Grpc      config.Grpc
+	I18n      i18np.I18n
+	Validator validation.Srv
+	Locales   []string
+	Logger    *slog.Logger
+
+	// DefaultLocale is used for calls accepting none of the Locales, en when empty
+	DefaultLocale string
+
+	// TrustedProxies may set the forwarded client IP metadata, see config.Rest.TrustedProxies
+	TrustedProxies []string
+
+	Todo *todo.Service
+}
+
+// Server serves the domain services over gRPC next to the REST API
+// It implements server.Listener
+type Server struct {
+	cnf      Config
+	srv      *gogrpc.Server
+	health   *health.Server
+	settings *atomic.Pointer[Settings]
+	logger   *slog.Logger
+}
+
+func New(cnf Config) *Server {
+	s := &Server{
+		cnf:      cnf,
+		health:   health.NewServer(),
+		settings: &atomic.Pointer[Settings]{},
+		logger:   slog.Default(),
+	}
+	if cnf.Logger != nil {
+		s.logger = cnf.Logger
+	}
+	if err := s.Reload(cnf.TrustedProxies, cnf.Locales, cnf.DefaultLocale); err != nil {
+		slog.Error("grpc: invalid trusted proxies, using defaults", "error", err)
+		trusted, _ := xip.ParseNetworks(rest.DefaultTrustedProxies)
+		s.settings.Store(&Settings{Locales: slices.Clone(cnf.Locales), DefaultLocale: cnf.DefaultLocale, TrustedProxies: trusted})
+	}
+	s.srv = gogrpc.NewServer(gogrpc.ChainUnaryInterceptor(
+		s.requestID(),
+		s.trace(),
+		s.locale(),
+		s.ipAddr(),
+		s.log(),
+		s.status(),
+		s.recover(),
+	))
+	if cnf.Todo != nil {
+		todov1.RegisterTodoServiceServer(s.srv, &todoServer{srv: cnf.Todo, validator: cnf.Validator})
+	}
+	healthpb.RegisterHealthServer(s.srv, s.health)
+	if cnf.Grpc.Reflection {
+		reflection.Register(s.srv)
+	}
+	return s
+}
+
+// Reload applies the hot reloadable gRPC settings
+// The local networks and Cloudflare ranges are trusted when proxies is empty
+func (s *Server) Reload(proxies []string, locales []string, defaultLocale string) error {
+	if len(proxies) == 0 {
+		proxies = rest.DefaultTrustedProxies
+	}
+	trusted, err := xip.ParseNetworks(proxies)
+	if err != nil {
+		return err
+	}
+	s.settings.Store(&Settings{Locales: slices.Clone(locales), DefaultLocale: defaultLocale, TrustedProxies: trusted})
+	return nil
+}
+
+func (s *Server) Listen() error {
+	addr := net.JoinHostPort(s.cnf.Grpc.Host, s.cnf.Grpc.Port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	slog.Info("idiogo grpc is running", "host", s.cnf.Grpc.Host, "port", s.cnf.Grpc.Port)
+	if err := s.srv.Serve(lis); err != nil && !errors.Is(err, gogrpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown reports NOT_SERVING to health checks and waits for running calls
+// The remaining calls are cancelled once ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
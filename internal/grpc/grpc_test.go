@@ -0,0 +1,330 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/salihguru/idiogo/internal/domain/todo"
+	todov1 "github.com/salihguru/idiogo/internal/grpc/proto/todo/v1"
+	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/state"
+	"github.com/salihguru/idiogo/pkg/validation"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeTodo answers View with view and records the context of the call
+type fakeTodo struct {
+	todov1.UnimplementedTodoServiceServer
+	view func(ctx context.Context) error
+	ctx  context.Context
+}
+
+func (f *fakeTodo) View(ctx context.Context, _ *todov1.ViewRequest) (*todov1.Todo, error) {
+	f.ctx = ctx
+	if f.view != nil {
+		if err := f.view(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &todov1.Todo{Id: "1"}, nil
+}
+
+// remoteListener reports remote as the address of every accepted connection
+type remoteListener struct {
+	*bufconn.Listener
+	remote net.Addr
+}
+
+type remoteConn struct {
+	net.Conn
+	remote net.Addr
+}
+
+func (c remoteConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func (l remoteListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return remoteConn{Conn: conn, remote: l.remote}, nil
+}
+
+// newTestConfig returns a server config translating with the bundled locales
+func newTestConfig(t *testing.T) Config {
+	t.Helper()
+	i18n, err := i18np.New(i18np.ConfigDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.Load("../../assets/locales", "en", "tr")
+	return Config{
+		I18n:      *i18n,
+		Validator: *validation.New(i18n),
+		Locales:   []string{"en", "tr"},
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+// dial serves impl over a bufconn listener whose peers connect from remote
+func dial(t *testing.T, cnf Config, impl todov1.TodoServiceServer, remote string) todov1.TodoServiceClient {
+	t.Helper()
+	s := New(cnf)
+	todov1.RegisterTodoServiceServer(s.srv, impl)
+	lis := bufconn.Listen(1 << 20)
+	addr, err := net.ResolveTCPAddr("tcp", remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.srv.Serve(remoteListener{Listener: lis, remote: addr}) }()
+	conn, err := gogrpc.Dial("bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		s.srv.Stop()
+	})
+	return todov1.NewTodoServiceClient(conn)
+}
+
+// details returns the ErrorInfo and BadRequest details of err
+func details(t *testing.T, err error) (*errdetails.ErrorInfo, *errdetails.BadRequest) {
+	t.Helper()
+	var (
+		info *errdetails.ErrorInfo
+		br   *errdetails.BadRequest
+	)
+	for _, d := range status.Convert(err).Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			br = d
+		}
+	}
+	return info, br
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+		reason  string
+	}{
+		{"domain result code", todo.NotFound(), codes.NotFound, "Task is not found.", "2000"},
+		{"wrapped result code", errors.Join(errors.New("view"), xrescode.Conflict()), codes.AlreadyExists, "The resource already exists.", "1003"},
+		{"classified error", context.DeadlineExceeded, codes.DeadlineExceeded, "", ""},
+		{"unknown error", errors.New("pq: connection refused"), codes.Internal, "Something went wrong, please try again later.", "1001"},
+		{"status", status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied, "denied", ""},
+	}
+	cnf := newTestConfig(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t, cnf, &fakeTodo{view: func(context.Context) error { return tt.err }}, "127.0.0.1:1")
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+			_, err := client.View(ctx, &todov1.ViewRequest{})
+			st := status.Convert(err)
+			if st.Code() != tt.code {
+				t.Errorf("code = %s, want %s", st.Code(), tt.code)
+			}
+			if tt.message != "" && st.Message() != tt.message {
+				t.Errorf("message = %q, want %q", st.Message(), tt.message)
+			}
+			info, _ := details(t, err)
+			if tt.code == codes.PermissionDenied {
+				if info != nil {
+					t.Errorf("a status passed through got details %v", info)
+				}
+				return
+			}
+			if info == nil {
+				t.Fatal("no ErrorInfo detail")
+			}
+			if tt.reason != "" && info.Reason != tt.reason {
+				t.Errorf("reason = %s, want %s", info.Reason, tt.reason)
+			}
+			if info.Domain != errorDomain || info.Metadata["request_id"] != "req-1" {
+				t.Errorf("ErrorInfo = %v", info)
+			}
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	cnf := newTestConfig(t)
+	client := dial(t, cnf, &todoServer{validator: cnf.Validator}, "127.0.0.1:1")
+
+	_, err := client.Create(context.Background(), &todov1.CreateRequest{Title: "a"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("code = %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+	info, br := details(t, err)
+	if info == nil || info.Reason != "1000" {
+		t.Errorf("ErrorInfo = %v, want reason 1000", info)
+	}
+	if br == nil || len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "Title" || br.FieldViolations[0].Description == "" {
+		t.Fatalf("BadRequest = %v, want one Title violation", br)
+	}
+
+	_, err = client.View(context.Background(), &todov1.ViewRequest{Id: "not-a-uuid"})
+	if _, br := details(t, err); br == nil || br.FieldViolations[0].Field != "ID" {
+		t.Errorf("BadRequest = %v, want an ID violation", br)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	cnf := newTestConfig(t)
+	client := dial(t, cnf, &fakeTodo{view: func(context.Context) error { panic("boom") }}, "127.0.0.1:1")
+	for i := 0; i < 2; i++ {
+		_, err := client.View(context.Background(), &todov1.ViewRequest{})
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("code = %s, want %s", status.Code(err), codes.Internal)
+		}
+		if info, _ := details(t, err); info == nil || info.Reason != "1001" {
+			t.Errorf("ErrorInfo = %v, want reason 1001", info)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		keep bool
+	}{
+		{"valid", "req-1", true},
+		{"invalid", "bad id", false},
+		{"missing", "", false},
+	}
+	fake := &fakeTodo{}
+	client := dial(t, newTestConfig(t), fake, "127.0.0.1:1")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.id != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", tt.id)
+			}
+			var header metadata.MD
+			if _, err := client.View(ctx, &todov1.ViewRequest{}, gogrpc.Header(&header)); err != nil {
+				t.Fatal(err)
+			}
+			got := header.Get("x-request-id")
+			if len(got) != 1 || got[0] != state.RequestID(fake.ctx) {
+				t.Fatalf("echoed %v, handler saw %q", got, state.RequestID(fake.ctx))
+			}
+			if (got[0] == tt.id) != tt.keep {
+				t.Errorf("request id = %q, kept = %v, want %v", got[0], got[0] == tt.id, tt.keep)
+			}
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		name string
+		md   []string
+		want string
+	}{
+		{"accept-language", []string{"accept-language", "tr-TR,en;q=0.8"}, "tr"},
+		{"first accepted", []string{"accept-language", "de, en"}, "en"},
+		{"lang", []string{"lang", "tr"}, "tr"},
+		{"accept-language before lang", []string{"accept-language", "en", "lang", "tr"}, "en"},
+		{"unsupported", []string{"accept-language", "de"}, "en"},
+		{"missing", nil, "en"},
+	}
+	fake := &fakeTodo{view: func(context.Context) error { return xrescode.NotFound() }}
+	client := dial(t, newTestConfig(t), fake, "127.0.0.1:1")
+	messages := map[string]string{"en": "The resource is not found.", "tr": "Kaynak bulunamadı."}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+			_, err := client.View(ctx, &todov1.ViewRequest{})
+			if got := state.LocaleStr(fake.ctx); got != tt.want {
+				t.Errorf("locale = %q, want %q", got, tt.want)
+			}
+			if got := status.Convert(err).Message(); got != messages[tt.want] {
+				t.Errorf("message = %q, want %q", got, messages[tt.want])
+			}
+		})
+	}
+}
+
+func TestLocaleDefault(t *testing.T) {
+	fake := &fakeTodo{view: func(context.Context) error { return xrescode.NotFound() }}
+	cnf := newTestConfig(t)
+	cnf.DefaultLocale = "tr"
+	client := dial(t, cnf, fake, "127.0.0.1:1")
+	for _, md := range [][]string{nil, {"accept-language", "de"}} {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), md...)
+		_, _ = client.View(ctx, &todov1.ViewRequest{})
+		if got := state.LocaleStr(fake.ctx); got != "tr" {
+			t.Errorf("locale of %v = %q, want the configured default tr", md, got)
+		}
+	}
+}
+
+func TestIPAddr(t *testing.T) {
+	tests := []struct {
+		name    string
+		remote  string
+		proxies []string
+		md      []string
+		want    string
+	}{
+		{"trusted proxy", "10.0.0.2:5000", nil, []string{"x-forwarded-for", "203.0.113.7, 10.0.0.2"}, "203.0.113.7"},
+		{"trusted cloudflare header", "127.0.0.1:5000", nil, []string{"cf-connecting-ip", "203.0.113.8"}, "203.0.113.8"},
+		{"invalid forwarded ip", "10.0.0.2:5000", nil, []string{"x-forwarded-for", "nope"}, "10.0.0.2"},
+		{"untrusted peer", "203.0.113.9:5000", nil, []string{"x-forwarded-for", "198.51.100.1"}, "203.0.113.9"},
+		{"configured proxies", "10.0.0.2:5000", []string{"192.0.2.0/24"}, []string{"x-forwarded-for", "198.51.100.1"}, "10.0.0.2"},
+		{"configured proxy", "192.0.2.10:5000", []string{"192.0.2.0/24"}, []string{"x-real-ip", "198.51.100.2"}, "198.51.100.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cnf := newTestConfig(t)
+			cnf.TrustedProxies = tt.proxies
+			fake := &fakeTodo{}
+			client := dial(t, cnf, fake, tt.remote)
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+			if _, err := client.View(ctx, &todov1.ViewRequest{}); err != nil {
+				t.Fatal(err)
+			}
+			if got := state.IP(fake.ctx); got != tt.want {
+				t.Errorf("ip = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerError(t *testing.T) {
+	failed := []codes.Code{codes.Internal, codes.Unknown, codes.Unavailable, codes.DeadlineExceeded, codes.DataLoss}
+	for _, code := range []codes.Code{
+		codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted,
+		codes.OutOfRange, codes.Unimplemented, codes.Unauthenticated,
+		codes.Internal, codes.Unknown, codes.Unavailable, codes.DeadlineExceeded, codes.DataLoss,
+	} {
+		if got, want := serverError(code), slices.Contains(failed, code); got != want {
+			t.Errorf("serverError(%s) = %v, want %v", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/salihguru/idiogo/internal/rest/middleware"
+	"github.com/salihguru/idiogo/pkg/locale"
+	"github.com/salihguru/idiogo/pkg/state"
+	"github.com/salihguru/idiogo/pkg/xip"
+	"github.com/salihguru/idiogo/pkg/xlog"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+	"github.com/salihguru/idiogo/pkg/xtrace"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// metadataRequestID is the request id metadata key, the gRPC form of X-Request-ID
+const metadataRequestID = "x-request-id"
+
+// incoming returns the comma joined values of the incoming metadata key
+func incoming(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return strings.Join(md.Get(key), ",")
+}
+
+// requestID keeps a valid incoming x-request-id or generates one and sends it back as a header
+func (s *Server) requestID() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		id := incoming(ctx, metadataRequestID)
+		if !middleware.ValidRequestID(id) {
+			id = uuid.NewString()
+		}
+		_ = gogrpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, id))
+		return handler(state.SetRequestID(ctx, id), req)
+	}
+}
+
+// trace continues the traceparent of the caller and opens a span named after the method
+// Only server side codes mark the span as failed, see serverError
+func (s *Server) trace() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		in := ctx
+		ctx, span := xtrace.Start(xtrace.Extract(in, func(key string) string { return incoming(in, key) }), info.FullMethod)
+		defer span.End()
+		xtrace.Inject(ctx, func(key, value string) {
+			_ = gogrpc.SetHeader(ctx, metadata.Pairs(key, value))
+		})
+		span.SetAttr("rpc.system", "grpc")
+		span.SetAttr("rpc.method", info.FullMethod)
+		res, err := handler(ctx, req)
+		code := status.Code(err)
+		span.SetAttr("rpc.grpc.status_code", int(code))
+		if serverError(code) {
+			span.RecordError(err)
+		}
+		return res, err
+	}
+}
+
+// serverError reports the codes that mark a span as failed, the gRPC form of a 5xx status
+// Client errors like NotFound or InvalidArgument are part of normal operation, as in middleware.NewTrace
+func serverError(code codes.Code) bool {
+	switch code {
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DeadlineExceeded, codes.DataLoss:
+		return true
+	}
+	return false
+}
+
+// locale picks the first accepted locale of the accept-language or lang metadata
+// Calls accepting none of them get the default locale, like the REST i18n middleware
+func (s *Server) locale() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		settings := s.settings.Load()
+		l := matchLocale(incoming(ctx, "accept-language"), settings.Locales)
+		if l == "" {
+			l = matchLocale(incoming(ctx, "lang"), settings.Locales)
+		}
+		if l == "" || !locale.IsLocale(l) {
+			l = settings.DefaultLocale
+		}
+		if l == "" {
+			l = middleware.DefaultLocale
+		}
+		return handler(state.SetLocale(ctx, l), req)
+	}
+}
+
+// matchLocale returns the first language of an Accept-Language value found in accepted
+func matchLocale(value string, accepted []string) string {
+	for _, part := range strings.Split(value, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if slices.Contains(accepted, lang) {
+			return lang
+		}
+	}
+	return ""
+}
+
+// ipAddr reads the forwarded client IP metadata only from trusted proxies
+// Calls from any other peer use the connection IP
+func (s *Server) ipAddr() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		var remote string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			remote = p.Addr.String()
+			if host, _, err := net.SplitHostPort(remote); err == nil {
+				remote = host
+			}
+		}
+		ip := remote
+		if s.settings.Load().TrustedProxies.Contains(remote) {
+			ip = xip.ClaimRealIP(func(name string) string { return incoming(ctx, name) }, remote)
+		}
+		return handler(state.SetIP(ctx, ip), req)
+	}
+}
+
+// log attaches the call scoped logger, see xlog.From
+func (s *Server) log() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		l := s.logger.With(
+			slog.String("request_id", state.RequestID(ctx)),
+			slog.String("method", info.FullMethod),
+			slog.String("ip", state.IP(ctx)),
+			slog.String("locale", state.LocaleStr(ctx)),
+		)
+		return handler(xlog.WithLogger(ctx, l), req)
+	}
+}
+
+// status converts the errors of the handler to gRPC statuses, see Server.toStatus
+func (s *Server) status() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return nil, s.toStatus(ctx, err)
+		}
+		return res, nil
+	}
+}
+
+// recover answers a panicking handler with the failed result code
+func (s *Server) recover() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				xlog.From(ctx).ErrorContext(ctx, "grpc handler panicked", "panic", r, "stack", string(debug.Stack()))
+				res, err = nil, xrescode.Failed(fmt.Errorf("panic in %s: %v", info.FullMethod, r))
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
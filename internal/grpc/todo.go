@@ -0,0 +1,138 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/salihguru/idiogo/internal/domain/todo"
+	todov1 "github.com/salihguru/idiogo/internal/grpc/proto/todo/v1"
+	"github.com/salihguru/idiogo/pkg/query"
+	"github.com/salihguru/idiogo/pkg/validation"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// todoServer maps todov1.TodoService calls to the todo.Service used by the REST API
+type todoServer struct {
+	todov1.UnimplementedTodoServiceServer
+	srv       *todo.Service
+	validator validation.Srv
+}
+
+func (s *todoServer) Create(ctx context.Context, req *todov1.CreateRequest) (*todov1.Todo, error) {
+	t, err := handle(ctx, s.validator, todo.CreateReq{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+	}, s.srv.Create)
+	if err != nil {
+		return nil, err
+	}
+	return todoToProto(t), nil
+}
+
+func (s *todoServer) View(ctx context.Context, req *todov1.ViewRequest) (*todov1.Todo, error) {
+	id, err := parseID(ctx, s.validator, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	t, err := handle(ctx, s.validator, todo.ViewReq{ID: id}, s.srv.View)
+	if err != nil {
+		return nil, err
+	}
+	return todoToProto(t), nil
+}
+
+func (s *todoServer) Find(ctx context.Context, req *todov1.FindRequest) (*todov1.FindResponse, error) {
+	listReq := todo.ListReq{
+		Filters: todo.Filters{Status: req.GetStatus(), Q: req.GetQ()},
+		SortReq: query.SortReq{Sort: req.GetSort()},
+	}
+	if req.Page != nil {
+		page := int(req.GetPage())
+		listReq.Page = &page
+	}
+	if req.Limit != nil {
+		limit := int(req.GetLimit())
+		listReq.Limit = &limit
+	}
+	page, err := handle(ctx, s.validator, listReq, s.srv.Find)
+	if err != nil {
+		return nil, err
+	}
+	res := &todov1.FindResponse{
+		Todos:      make([]*todov1.Todo, 0, len(page.Items)),
+		Page:       int32(page.Page),
+		Limit:      int32(page.Limit),
+		Total:      page.Total,
+		TotalPages: int32(page.TotalPages),
+		HasNext:    page.HasNext,
+	}
+	for _, t := range page.Items {
+		res.Todos = append(res.Todos, todoToProto(t))
+	}
+	return res, nil
+}
+
+func (s *todoServer) Update(ctx context.Context, req *todov1.UpdateRequest) (*todov1.Todo, error) {
+	id, err := parseID(ctx, s.validator, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	t, err := handle(ctx, s.validator, todo.UpdateReq{
+		ID:          id,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+	}, s.srv.Update)
+	if err != nil {
+		return nil, err
+	}
+	return todoToProto(t), nil
+}
+
+func (s *todoServer) Delete(ctx context.Context, req *todov1.DeleteRequest) (*emptypb.Empty, error) {
+	id, err := parseID(ctx, s.validator, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.Delete(ctx, todo.DeleteReq{ID: id}); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func todoToProto(t *todo.Todo) *todov1.Todo {
+	res := &todov1.Todo{
+		Id:          t.ID.String(),
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      string(t.Status),
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+	}
+	if t.UpdatedAt != nil {
+		res.UpdatedAt = timestamppb.New(*t.UpdatedAt)
+	}
+	return res
+}
+
+// handle validates req before calling fn, the gRPC form of rest.WithValidation
+func handle[Req any, Res any](ctx context.Context, v validation.Srv, req Req, fn func(context.Context, Req) (Res, error)) (Res, error) {
+	if err := v.ValidateStruct(ctx, req); err != nil {
+		var zero Res
+		return zero, err
+	}
+	return fn(ctx, req)
+}
+
+// idReq validates the string ids of request messages
+type idReq struct {
+	ID string `validate:"required,uuid"`
+}
+
+// parseID parses a request message id, answering invalid ids with a validation error
+func parseID(ctx context.Context, v validation.Srv, id string) (uuid.UUID, error) {
+	if err := v.ValidateStruct(ctx, idReq{ID: id}); err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(id)
+}
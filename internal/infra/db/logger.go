@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/salihguru/idiogo/pkg/xlog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SlowThreshold is the query duration logged as a warning
+const SlowThreshold = 200 * time.Millisecond
+
+// slogLogger bridges gorm logs to the request scoped logger of the context
+type slogLogger struct {
+	level logger.LogLevel
+}
+
+// NewLogger creates a gorm logger writing through xlog.From(ctx)
+func NewLogger(level logger.LogLevel) logger.Interface {
+	return &slogLogger{level: level}
+}
+
+func (l *slogLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &slogLogger{level: level}
+}
+
+func (l *slogLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		xlog.From(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *slogLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		xlog.From(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *slogLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		xlog.From(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *slogLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	log := func(level slog.Level, msg string, extra ...any) {
+		sql, rows := fc()
+		attrs := append([]any{
+			slog.String("sql", sql),
+			slog.Int64("rows", rows),
+			slog.Duration("elapsed", elapsed),
+		}, extra...)
+		xlog.From(ctx).Log(ctx, level, msg, attrs...)
+	}
+	switch {
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		log(slog.LevelError, "query failed", slog.String("error", err.Error()))
+	case elapsed > SlowThreshold && l.level >= logger.Warn:
+		log(slog.LevelWarn, "slow query", slog.Duration("threshold", SlowThreshold))
+	case l.level >= logger.Info:
+		log(slog.LevelInfo, "query")
+	}
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/salihguru/idiogo/pkg/metrics"
+	"gorm.io/gorm"
+)
+
+const metricsStartKey = "metrics:start"
+
+// RegisterMetrics records query durations and errors by operation with gorm callbacks
+// and exposes the connection pool stats of sqlDB
+func RegisterMetrics(gormDB *gorm.DB, sqlDB *sql.DB, reg *metrics.Registry) error {
+	duration := reg.Histogram("db_query_duration_seconds", "Database query duration in seconds.", metrics.DefBuckets, "operation")
+	errs := reg.Counter("db_query_errors_total", "Number of failed database queries.", "operation")
+
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(metricsStartKey, time.Now())
+	}
+	after := func(op string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			if v, ok := tx.InstanceGet(metricsStartKey); ok {
+				if start, ok := v.(time.Time); ok {
+					duration.Observe(time.Since(start).Seconds(), op)
+				}
+			}
+			if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+				errs.Inc(op)
+			}
+		}
+	}
+	cb := gormDB.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("metrics:before_create", before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	stat := func(fn func(sql.DBStats) float64) func() float64 {
+		return func() float64 { return fn(sqlDB.Stats()) }
+	}
+	reg.GaugeFunc("db_max_open_connections", "Maximum number of open connections to the database.", stat(func(s sql.DBStats) float64 {
+		return float64(s.MaxOpenConnections)
+	}))
+	reg.GaugeFunc("db_open_connections", "Number of established connections, in use and idle.", stat(func(s sql.DBStats) float64 {
+		return float64(s.OpenConnections)
+	}))
+	reg.GaugeFunc("db_in_use_connections", "Number of connections currently in use.", stat(func(s sql.DBStats) float64 {
+		return float64(s.InUse)
+	}))
+	reg.GaugeFunc("db_idle_connections", "Number of idle connections.", stat(func(s sql.DBStats) float64 {
+		return float64(s.Idle)
+	}))
+	reg.CounterFunc("db_wait_count_total", "Number of connections waited for.", stat(func(s sql.DBStats) float64 {
+		return float64(s.WaitCount)
+	}))
+	reg.CounterFunc("db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", stat(func(s sql.DBStats) float64 {
+		return s.WaitDuration.Seconds()
+	}))
+	reg.CounterFunc("db_max_idle_closed_total", "Number of connections closed due to the idle limit.", stat(func(s sql.DBStats) float64 {
+		return float64(s.MaxIdleClosed)
+	}))
+	return nil
+}
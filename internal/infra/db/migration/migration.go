@@ -0,0 +1,92 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// fileRegexp matches migration file names like 0001_create_todos.up.sql
+var fileRegexp = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a single versioned schema change
+// Up is applied when migrating forward, Down when rolling back
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum returns the sha256 of the up script
+// It is stored in schema_migrations to detect edited migrations
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Load reads every migration file in dir and returns them ordered by version
+// Each version must have an up script, the down script is optional
+// Files other than .sql are ignored, .sql files with another name or a version defined twice are errors
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := fileRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_name.up.sql or 0001_name.down.sql", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d: name mismatch between %q and %q", version, m.Name, match[2])
+		}
+		script := &m.Down
+		if match[3] == "up" {
+			script = &m.Up
+		}
+		key := fmt.Sprintf("%d.%s", version, match[3])
+		if seen[key] {
+			return nil, fmt.Errorf("migration %s: duplicate %s script of version %d", entry.Name(), match[3], version)
+		}
+		seen[key] = true
+		*script = string(content)
+	}
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s: missing up script", m.Version, m.Name)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
@@ -0,0 +1,111 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func file(s string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(s)}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0010_add_index.up.sql":      file("CREATE INDEX"),
+		"m/0002_create_todos.up.sql":   file("CREATE TABLE todos"),
+		"m/0002_create_todos.down.sql": file("DROP TABLE todos"),
+		"m/0001_init.up.sql":           file("SELECT 1"),
+		"m/README.md":                  file("notes"),
+		"m/sub/0003_nested.up.sql":     file("SELECT 3"),
+	}
+	list, err := Load(fsys, "m")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	var versions []int64
+	for _, m := range list {
+		versions = append(versions, m.Version)
+	}
+	if len(list) != 3 || versions[0] != 1 || versions[1] != 2 || versions[2] != 10 {
+		t.Fatalf("Load() versions = %v, want [1 2 10]", versions)
+	}
+	if list[1].Name != "create_todos" || list[1].Up != "CREATE TABLE todos" || list[1].Down != "DROP TABLE todos" {
+		t.Errorf("Load() migration 2 = %+v", list[1])
+	}
+	if list[2].Down != "" {
+		t.Errorf("Load() migration 10 has down %q, want none", list[2].Down)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "missing up script",
+			fsys: fstest.MapFS{"m/0001_init.down.sql": file("DROP")},
+			want: "missing up script",
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"m/0001_init.up.sql": file("A"),
+				"m/001_init.up.sql":  file("B"),
+			},
+			want: "duplicate up script of version 1",
+		},
+		{
+			name: "name mismatch",
+			fsys: fstest.MapFS{
+				"m/0001_init.up.sql":    file("A"),
+				"m/0001_other.down.sql": file("B"),
+			},
+			want: "name mismatch",
+		},
+		{
+			name: "bad file name",
+			fsys: fstest.MapFS{"m/0001_init.sql": file("A")},
+			want: "name must look like",
+		},
+		{
+			name: "bad version",
+			fsys: fstest.MapFS{"m/99999999999999999999_init.up.sql": file("A")},
+			want: "invalid version",
+		},
+		{
+			name: "missing dir",
+			fsys: fstest.MapFS{},
+			want: "m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys, "m")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Load() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	m := Migration{Version: 1, Name: "init", Up: "CREATE TABLE todos", Down: "DROP TABLE todos"}
+	// the checksums stored in schema_migrations depend on this value
+	const want = "8c6f19f942a0f81ff6e97a61e1e5f44ce2610718bc0ee9eed7d5848638d966da"
+	got := m.Checksum()
+	if got != want {
+		t.Fatalf("Checksum() = %q, want %q", got, want)
+	}
+	other := m
+	other.Down, other.Name, other.Version = "", "renamed", 2
+	if other.Checksum() != got {
+		t.Error("Checksum() depends on more than the up script")
+	}
+	other.Up += " "
+	if other.Checksum() == got {
+		t.Error("Checksum() ignores an edited up script")
+	}
+}
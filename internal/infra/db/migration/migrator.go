@@ -0,0 +1,245 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+const (
+	// Table is the name of the table that tracks applied migrations
+	Table = "schema_migrations"
+
+	// lockID is the postgres advisory lock key held while migrating
+	// It keeps two instances from running migrations at the same time
+	lockID int64 = 7_245_118_302
+)
+
+// ErrChecksumMismatch is returned when an applied migration was edited afterwards
+var ErrChecksumMismatch = errors.New("migration checksum mismatch")
+
+// ErrNoDownScript is returned when rolling back a migration without a down script
+var ErrNoDownScript = errors.New("migration has no down script")
+
+// Status describes the state of a single migration
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Modified  bool
+}
+
+type applied struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrator runs versioned migrations against a postgres database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New loads the migrations in dir from fsys
+// example: migration.New(db, assets.Migrations, "migrations")
+func New(db *sql.DB, fsys fs.FS, dir string) (*Migrator, error) {
+	migrations, err := Load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order
+// It returns the migrations that were applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(state); err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := state[mig.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the last n applied migrations in reverse version order
+// It returns the migrations that were rolled back
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < n; i-- {
+			mig := m.migrations[i]
+			if _, ok := state[mig.Version]; !ok {
+				continue
+			}
+			if err := m.revert(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Redo rolls back the last applied migration and applies it again
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	var redone *Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := state[mig.Version]; !ok {
+				continue
+			}
+			if err := m.revert(ctx, conn, mig); err != nil {
+				return err
+			}
+			if err := m.apply(ctx, conn, mig); err != nil {
+				return err
+			}
+			redone = &mig
+			return nil
+		}
+		return nil
+	})
+	return redone, err
+}
+
+// Status reports every known migration and whether it has been applied
+// It waits for a migration run of another process to finish
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var list []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		list = make([]Status, 0, len(m.migrations))
+		for _, mig := range m.migrations {
+			st := Status{Version: mig.Version, Name: mig.Name}
+			if a, ok := state[mig.Version]; ok {
+				st.Applied = true
+				st.AppliedAt = &a.appliedAt
+				st.Modified = a.checksum != mig.Checksum()
+			}
+			list = append(list, st)
+		}
+		return nil
+	})
+	return list, err
+}
+
+func (m *Migrator) verify(state map[int64]applied) error {
+	for _, mig := range m.migrations {
+		a, ok := state[mig.Version]
+		if ok && a.checksum != mig.Checksum() {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, mig.Version, mig.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	return inTx(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+			return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO "+Table+" (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)",
+			mig.Version, mig.Name, mig.Checksum(), time.Now())
+		return err
+	})
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if mig.Down == "" {
+		return fmt.Errorf("%w: %d_%s", ErrNoDownScript, mig.Version, mig.Name)
+	}
+	return inTx(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+			return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+Table+" WHERE version = $1", mig.Version)
+		return err
+	})
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]applied, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, checksum, applied_at FROM "+Table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	state := make(map[int64]applied)
+	for rows.Next() {
+		var version int64
+		var a applied
+		if err := rows.Scan(&version, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		state[version] = a
+	}
+	return state, rows.Err()
+}
+
+// withLock runs fn on a dedicated connection holding the advisory lock for the whole run
+// The lock is session scoped so it must be taken and released on the same connection
+// The tracking table is created under the lock, so replicas starting together don't race on its DDL
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockID)
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+Table+` (
+		version    bigint PRIMARY KEY,
+		name       text NOT NULL,
+		checksum   text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -3,17 +3,28 @@ package migration
 import (
 	"context"
 
-	"github.com/salihguru/idiogo/internal/domain/todo"
+	"github.com/salihguru/idiogo/assets"
 	"gorm.io/gorm"
 )
 
+// Dir is the directory of the embedded migrations inside assets.Migrations
+const Dir = "migrations"
+
+// NewFromGorm creates a Migrator for the embedded migrations using the connection pool of db
+func NewFromGorm(db *gorm.DB) (*Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return New(sqlDB, assets.Migrations, Dir)
+}
+
+// RunSql applies every pending embedded migration
 func RunSql(ctx context.Context, db *gorm.DB) error {
-	err := db.AutoMigrate(
-		&todo.Todo{},
-	)
+	m, err := NewFromGorm(db)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	_, err = m.Up(ctx)
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	_ "github.com/lib/pq"
+	"github.com/salihguru/idiogo/pkg/metrics"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -18,13 +19,16 @@ type PostgresConfig struct {
 	DBName   string
 	SSLMode  string
 	Debug    bool
+
+	// Metrics records query and connection pool metrics when set
+	Metrics *metrics.Registry
 }
 
 func NewPostgres(ctx context.Context, cnf PostgresConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", cnf.Host, cnf.Port, cnf.User, cnf.Password, cnf.DBName, cnf.SSLMode)
-	conf := &gorm.Config{}
+	conf := &gorm.Config{Logger: NewLogger(logger.Warn)}
 	if cnf.Debug {
-		conf.Logger = logger.Default.LogMode(logger.Info)
+		conf.Logger = NewLogger(logger.Info)
 	}
 	gormDB, err := gorm.Open(postgres.New(postgres.Config{
 		DSN:                  dsn,
@@ -33,5 +37,17 @@ func NewPostgres(ctx context.Context, cnf PostgresConfig) (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := RegisterTracing(gormDB); err != nil {
+		return nil, err
+	}
+	if cnf.Metrics != nil {
+		sqlDB, err := gormDB.DB()
+		if err != nil {
+			return nil, err
+		}
+		if err := RegisterMetrics(gormDB, sqlDB, cnf.Metrics); err != nil {
+			return nil, err
+		}
+	}
 	return gormDB, nil
 }
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/salihguru/idiogo/pkg/xtrace"
+	"gorm.io/gorm"
+)
+
+const traceSpanKey = "trace:span"
+
+// RegisterTracing opens a span around every gorm operation with the statement and affected rows
+func RegisterTracing(gormDB *gorm.DB) error {
+	before := func(op string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			ctx, span := xtrace.Start(tx.Statement.Context, "db."+op)
+			if span == nil {
+				return
+			}
+			tx.Statement.Context = ctx
+			tx.InstanceSet(traceSpanKey, span)
+		}
+	}
+	after := func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(traceSpanKey)
+		if !ok {
+			return
+		}
+		span, ok := v.(*xtrace.Span)
+		if !ok {
+			return
+		}
+		span.SetAttr("db.system", "postgresql")
+		span.SetAttr("db.table", tx.Statement.Table)
+		span.SetAttr("db.statement", tx.Statement.SQL.String())
+		span.SetAttr("db.rows_affected", tx.RowsAffected)
+		if !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(tx.Error)
+		}
+		span.End()
+	}
+	cb := gormDB.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("trace:before_create", before("create")),
+		cb.Create().After("gorm:create").Register("trace:after_create", after),
+		cb.Query().Before("gorm:query").Register("trace:before_query", before("query")),
+		cb.Query().After("gorm:query").Register("trace:after_query", after),
+		cb.Update().Before("gorm:update").Register("trace:before_update", before("update")),
+		cb.Update().After("gorm:update").Register("trace:after_update", after),
+		cb.Delete().Before("gorm:delete").Register("trace:before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register("trace:after_delete", after),
+		cb.Row().Before("gorm:row").Register("trace:before_row", before("row")),
+		cb.Row().After("gorm:row").Register("trace:after_row", after),
+		cb.Raw().Before("gorm:raw").Register("trace:before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register("trace:after_raw", after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
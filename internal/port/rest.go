@@ -9,6 +9,7 @@ import (
 type RestService interface {
 	IpAddr() fiber.Handler
 	I18n() fiber.Handler
+	AccessLog() fiber.Handler
 	RateLimit(limit int) fiber.Handler
 	Timeout(fn fiber.Handler) fiber.Handler
 	ValidateStruct() ValidatorFn
@@ -2,9 +2,17 @@ package rest
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/list"
+	"github.com/salihguru/idiogo/pkg/xtrace"
 )
 
 type Cookie = fiber.Cookie
@@ -20,8 +28,9 @@ type ReqHandler[I any] = func(ctx context.Context, input I) error
 type HandlerWithRes[I any, O any] = func(ctx context.Context, input I) (O, error)
 
 func Create[I any](h ReqHandler[I]) Handler[I] {
+	name := funcName(h)
 	return func(fctx *fiber.Ctx, payload I) error {
-		if err := h(fctx.UserContext(), payload); err != nil {
+		if err := call(fctx, name, h, payload); err != nil {
 			return err
 		}
 		return fctx.SendStatus(fiber.StatusCreated)
@@ -29,8 +38,9 @@ func Create[I any](h ReqHandler[I]) Handler[I] {
 }
 
 func CreateResponds[I any, O any](h HandlerWithRes[I, O]) Handler[I] {
+	name := funcName(h)
 	return func(fctx *fiber.Ctx, payload I) error {
-		res, err := h(fctx.UserContext(), payload)
+		res, err := callWithRes(fctx, name, h, payload)
 		if err != nil {
 			return err
 		}
@@ -39,8 +49,9 @@ func CreateResponds[I any, O any](h HandlerWithRes[I, O]) Handler[I] {
 }
 
 func Void[I any](h ReqHandler[I]) Handler[I] {
+	name := funcName(h)
 	return func(fctx *fiber.Ctx, payload I) error {
-		if err := h(fctx.UserContext(), payload); err != nil {
+		if err := call(fctx, name, h, payload); err != nil {
 			return err
 		}
 		return fctx.SendStatus(fiber.StatusNoContent)
@@ -48,8 +59,9 @@ func Void[I any](h ReqHandler[I]) Handler[I] {
 }
 
 func Todo[I any](h ReqHandler[I]) Handler[I] {
+	name := funcName(h)
 	return func(fctx *fiber.Ctx, payload I) error {
-		if err := h(fctx.UserContext(), payload); err != nil {
+		if err := call(fctx, name, h, payload); err != nil {
 			return err
 		}
 		return fctx.SendStatus(fiber.StatusOK)
@@ -57,8 +69,9 @@ func Todo[I any](h ReqHandler[I]) Handler[I] {
 }
 
 func Data[I any, O any](h HandlerWithRes[I, O]) Handler[I] {
+	name := funcName(h)
 	return func(fctx *fiber.Ctx, payload I) error {
-		res, err := h(fctx.UserContext(), payload)
+		res, err := callWithRes(fctx, name, h, payload)
 		if err != nil {
 			return err
 		}
@@ -66,6 +79,39 @@ func Data[I any, O any](h HandlerWithRes[I, O]) Handler[I] {
 	}
 }
 
+// call runs the service handler in a span named after it
+func call[I any](fctx *fiber.Ctx, name string, h ReqHandler[I], payload I) error {
+	ctx, span := xtrace.Start(fctx.UserContext(), name)
+	defer span.End()
+	err := h(ctx, payload)
+	span.RecordError(err)
+	return err
+}
+
+// callWithRes runs the service handler in a span named after it
+func callWithRes[I any, O any](fctx *fiber.Ctx, name string, h HandlerWithRes[I, O], payload I) (O, error) {
+	ctx, span := xtrace.Start(fctx.UserContext(), name)
+	defer span.End()
+	res, err := h(ctx, payload)
+	span.RecordError(err)
+	return res, err
+}
+
+// funcName returns the short name of fn, e.g. todo.(*Service).Create
+func funcName(fn any) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "handler"
+	}
+	name := strings.TrimSuffix(f.Name(), "-fm")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// respond answers res with defStatus unless it is a *Response setting its own
+// Pages of the list package also get a Link header to their neighbours
 func respond[O any](fctx *fiber.Ctx, res O, defStatus int) error {
 	if response, ok := any(res).(*Response); ok {
 		for k, v := range response.Headers {
@@ -81,11 +127,71 @@ func respond[O any](fctx *fiber.Ctx, res O, defStatus int) error {
 		if response.Data == nil {
 			return fctx.SendStatus(status)
 		}
+		setLinks(fctx, response.Data)
 		return fctx.Status(status).JSON(response.Data)
 	}
+	setLinks(fctx, res)
 	return fctx.Status(defStatus).JSON(res)
 }
 
+// setLinks sets the RFC 8288 Link header of a page, linking the first, previous, next and last pages
+// Cursor pages link the first, previous and next pages
+// The links are relative to the request and keep its query string apart from the pagination parameters
+func setLinks(fctx *fiber.Ctx, res any) {
+	if res == nil || !isPage(res) {
+		return
+	}
+	query, err := url.ParseQuery(string(fctx.Request().URI().QueryString()))
+	if err != nil {
+		return
+	}
+	link := func(rel string, params ...string) string {
+		query.Del("page")
+		query.Del("cursor")
+		for i := 0; i < len(params); i += 2 {
+			query.Set(params[i], params[i+1])
+		}
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, fctx.Path(), query.Encode(), rel)
+	}
+	var links []string
+	switch pager := res.(type) {
+	case list.Pager:
+		page, limit, totalPages := pager.PageInfo()
+		last := max(totalPages, 1)
+		pageLink := func(page int, rel string) string {
+			return link(rel, "page", strconv.Itoa(page), "limit", strconv.Itoa(limit))
+		}
+		links = append(links, pageLink(1, "first"))
+		if page > 1 {
+			links = append(links, pageLink(min(page-1, last), "prev"))
+		}
+		if page < totalPages {
+			links = append(links, pageLink(page+1, "next"))
+		}
+		links = append(links, pageLink(last, "last"))
+	case list.CursorPager:
+		limit, next, prev := pager.CursorInfo()
+		links = append(links, link("first", "limit", strconv.Itoa(limit)))
+		if prev != "" {
+			links = append(links, link("prev", "cursor", prev, "limit", strconv.Itoa(limit)))
+		}
+		if next != "" {
+			links = append(links, link("next", "cursor", next, "limit", strconv.Itoa(limit)))
+		}
+	}
+	fctx.Set(fiber.HeaderLink, strings.Join(links, ", "))
+}
+
+// isPage reports whether res is a non nil page of the list package
+func isPage(res any) bool {
+	switch res.(type) {
+	case list.Pager, list.CursorPager:
+		v := reflect.ValueOf(res)
+		return v.Kind() != reflect.Pointer || !v.IsNil()
+	}
+	return false
+}
+
 type CookieOpts struct {
 	Value   string
 	Name    string
@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/list"
+)
+
+func TestSetLinks(t *testing.T) {
+	// the filters are kept, page and cursor are replaced
+	const target = "/todos?filter[title][eq]=milk&status=pending&page=7&cursor=old&limit=10"
+	const filters = "filter%5Btitle%5D%5Beq%5D=milk"
+	pageLink := func(page, rel string) string {
+		return `</todos?` + filters + `&limit=10&page=` + page + `&status=pending>; rel="` + rel + `"`
+	}
+	cursorLink := func(cursor, rel string) string {
+		query := filters + "&limit=10&status=pending"
+		if cursor != "" {
+			query = "cursor=" + cursor + "&" + query
+		}
+		return `</todos?` + query + `>; rel="` + rel + `"`
+	}
+
+	tests := []struct {
+		name string
+		res  any
+		want []string
+	}{
+		{
+			name: "middle page",
+			res:  &list.Page[int]{Page: 2, Limit: 10, TotalPages: 3},
+			want: []string{pageLink("1", "first"), pageLink("1", "prev"), pageLink("3", "next"), pageLink("3", "last")},
+		},
+		{
+			name: "last page",
+			res:  &list.Page[int]{Page: 3, Limit: 10, TotalPages: 3},
+			want: []string{pageLink("1", "first"), pageLink("2", "prev"), pageLink("3", "last")},
+		},
+		{
+			name: "no pages",
+			res:  &list.Page[int]{Page: 1, Limit: 10, TotalPages: 0},
+			want: []string{pageLink("1", "first"), pageLink("1", "last")},
+		},
+		{
+			name: "past the last of no pages",
+			res:  &list.Page[int]{Page: 3, Limit: 10, TotalPages: 0},
+			want: []string{pageLink("1", "first"), pageLink("1", "prev"), pageLink("1", "last")},
+		},
+		{
+			name: "cursor page",
+			res:  &list.CursorPage[int]{Limit: 10, NextCursor: "n", PrevCursor: "p"},
+			want: []string{cursorLink("", "first"), cursorLink("p", "prev"), cursorLink("n", "next")},
+		},
+		{
+			name: "first cursor page",
+			res:  &list.CursorPage[int]{Limit: 10, NextCursor: "n"},
+			want: []string{cursorLink("", "first"), cursorLink("n", "next")},
+		},
+		{
+			name: "not a page",
+			res:  fiber.Map{"page": 2},
+		},
+		{
+			name: "nil page",
+			res:  (*list.Page[int])(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/todos", func(c *fiber.Ctx) error { return respond(c, tt.res, fiber.StatusOK) })
+			res, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := res.Header.Get(fiber.HeaderLink), strings.Join(tt.want, ", "); got != want {
+				t.Errorf("Link =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
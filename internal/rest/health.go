@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/internal/port"
+	"github.com/salihguru/idiogo/pkg/health"
+)
+
+// Health endpoint paths
+const (
+	PathLiveness  = "/healthz"
+	PathReadiness = "/readyz"
+	PathHealth    = "/health"
+)
+
+type healthRouter struct {
+	checker *health.Checker
+}
+
+// NewHealthRouter exposes the liveness, readiness and detailed health endpoints
+func NewHealthRouter(checker *health.Checker) Router {
+	return &healthRouter{checker: checker}
+}
+
+func (h *healthRouter) RegisterRoutes(_ port.RestService, router fiber.Router) {
+	router.Get(PathLiveness, h.liveness)
+	router.Get(PathReadiness, h.readiness)
+	router.Get(PathHealth, h.report)
+}
+
+// liveness reports that the process is able to serve requests at all
+func (h *healthRouter) liveness(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": health.StatusUp})
+}
+
+// readiness fails while a dependency is down or the application is draining
+func (h *healthRouter) readiness(c *fiber.Ctx) error {
+	if h.checker.Draining() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": health.StatusDown, "draining": true})
+	}
+	report := h.checker.Check(c.UserContext())
+	if !report.Ready {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": report.Status})
+	}
+	return c.JSON(fiber.Map{"status": report.Status})
+}
+
+// report returns every check with its latency
+func (h *healthRouter) report(c *fiber.Ctx) error {
+	report := h.checker.Check(c.UserContext())
+	status := fiber.StatusOK
+	if !report.Ready {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(report)
+}
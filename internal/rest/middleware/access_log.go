@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/state"
+)
+
+// accessLoggedKey marks a request as logged so nested access logs don't log it twice
+const accessLoggedKey = "access_logged"
+
+// AccessLogConfig configures the access log
+type AccessLogConfig struct {
+	// Enabled turns the access log on
+	Enabled bool
+
+	// SampleRate is the share of requests logged, between 0 and 1
+	// Server errors are always logged
+	SampleRate float64
+
+	// Exclude lists the paths never logged, a trailing * matches a prefix
+	Exclude []string
+}
+
+func (c AccessLogConfig) excluded(path string) bool {
+	for _, p := range c.Exclude {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+			continue
+		}
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAccessLog logs one line per request with base
+// The config is read on every request so it can change at runtime
+// The line is written once NewErrors has sent the response, see OnDone
+func NewAccessLog(base *slog.Logger, current func() AccessLogConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		cnf := current()
+		if !cnf.Enabled || c.Locals(accessLoggedKey) != nil || cnf.excluded(c.Path()) {
+			return c.Next()
+		}
+		c.Locals(accessLoggedKey, true)
+		start := time.Now()
+		OnDone(c, func(error) {
+			status := c.Response().StatusCode()
+			if status < fiber.StatusInternalServerError && rand.Float64() >= cnf.SampleRate {
+				return
+			}
+			level := slog.LevelInfo
+			if status >= fiber.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			ctx := c.UserContext()
+			base.LogAttrs(ctx, level, "access",
+				slog.String("method", c.Method()),
+				slog.String("route", c.Route().Path),
+				slog.String("path", c.Path()),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int("bytes_in", len(c.Request().Body())),
+				slog.Int("bytes_out", len(c.Response().Body())),
+				slog.String("ip", state.IP(ctx)),
+				slog.String("request_id", state.RequestID(ctx)),
+				slog.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+			)
+		})
+		return c.Next()
+	}
+}
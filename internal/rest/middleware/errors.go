@@ -0,0 +1,34 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// doneKey holds the hooks of a request run once its response is final
+const doneKey = "response_done"
+
+// NewErrors answers the error returned by the next handlers with the app ErrorHandler
+// It is the only place errors are handled, so it must be mounted before every other middleware
+// Hooks registered with OnDone run afterwards and see the status that is sent
+func NewErrors() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var hooks []func(err error)
+		c.Locals(doneKey, &hooks)
+		err := c.Next()
+		if err != nil {
+			if hErr := c.App().ErrorHandler(c, err); hErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i](err)
+		}
+		return nil
+	}
+}
+
+// OnDone runs fn once NewErrors has written the response, fn gets the error the handlers returned
+// Hooks run in reverse order like deferred calls, nothing is run when NewErrors isn't mounted
+func OnDone(c *fiber.Ctx, fn func(err error)) {
+	if hooks, ok := c.Locals(doneKey).(*[]func(err error)); ok {
+		*hooks = append(*hooks, fn)
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestErrors(t *testing.T) {
+	var handled int
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			handled++
+			var e *fiber.Error
+			if errors.As(err, &e) {
+				return c.Status(e.Code).SendString(e.Message)
+			}
+			return c.Status(fiber.StatusInternalServerError).SendString("failed")
+		},
+	})
+	var statuses []int
+	var errs []error
+	observe := func(c *fiber.Ctx) error {
+		OnDone(c, func(err error) {
+			statuses = append(statuses, c.Response().StatusCode())
+			errs = append(errs, err)
+		})
+		return c.Next()
+	}
+	app.Use(NewErrors(), observe, observe)
+	app.Get("/ok", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/teapot", func(*fiber.Ctx) error { return fiber.NewError(fiber.StatusTeapot, "teapot") })
+	app.Get("/fail", func(*fiber.Ctx) error { return errors.New("db down") })
+
+	tests := []struct {
+		path    string
+		status  int
+		handled int
+		err     bool
+	}{
+		{"/ok", fiber.StatusOK, 0, false},
+		{"/teapot", fiber.StatusTeapot, 1, true},
+		{"/fail", fiber.StatusInternalServerError, 1, true},
+		{"/missing", fiber.StatusNotFound, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			handled, statuses, errs = 0, nil, nil
+			res, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.status)
+			}
+			if handled != tt.handled {
+				t.Errorf("error handler ran %d times, want %d", handled, tt.handled)
+			}
+			if len(statuses) != 2 || statuses[0] != tt.status || statuses[1] != tt.status {
+				t.Errorf("hooks saw %v, want the sent status %d twice", statuses, tt.status)
+			}
+			for _, e := range errs {
+				if (e != nil) != tt.err {
+					t.Errorf("hook error = %v, want error %v", e, tt.err)
+				}
+			}
+		})
+	}
+}
+
+func TestOnDoneWithoutErrors(t *testing.T) {
+	app := fiber.New()
+	called := false
+	app.Get("/", func(c *fiber.Ctx) error {
+		OnDone(c, func(error) { called = true })
+		return c.SendString("ok")
+	})
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Error("hook ran without NewErrors")
+	}
+}
@@ -8,9 +8,25 @@ import (
 	"github.com/salihguru/idiogo/pkg/state"
 )
 
-func NewI18n(locales []string) fiber.Handler {
+// DefaultLocale is used when I18nConfig.Default is empty
+const DefaultLocale = "en"
+
+// I18nConfig are the accepted locales and the one used when a request accepts none of them
+type I18nConfig struct {
+	Locales []string
+	Default string
+}
+
+func NewI18n(locales []string, def string) fiber.Handler {
+	return NewDynamicI18n(func() I18nConfig { return I18nConfig{Locales: locales, Default: def} })
+}
+
+// NewDynamicI18n is NewI18n reading the accepted locales on every request
+func NewDynamicI18n(current func() I18nConfig) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var l string
+		cnf := current()
+		locales := cnf.Locales
 
 		if l == "" {
 			acceptedLanguages := locales
@@ -37,7 +53,10 @@ func NewI18n(locales []string) fiber.Handler {
 
 		// Ensure we have a valid locale
 		if l == "" || !locale.IsLocale(l) {
-			l = "en"
+			l = cnf.Default
+		}
+		if l == "" {
+			l = DefaultLocale
 		}
 
 		c.SetUserContext(state.SetLocale(c.UserContext(), l))
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/state"
+)
+
+func TestI18nDefault(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    string
+		header string
+		want   string
+	}{
+		{"accepted", "tr", "en", "en"},
+		{"configured default", "tr", "", "tr"},
+		{"empty default", "", "", DefaultLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(NewI18n([]string{"en", "tr"}, tt.def))
+			var got string
+			app.Get("/", func(c *fiber.Ctx) error {
+				got = state.LocaleStr(c.UserContext())
+				return nil
+			})
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.header)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("locale = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
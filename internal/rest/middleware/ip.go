@@ -13,3 +13,16 @@ func IpAddr(c *fiber.Ctx) error {
 	c.SetUserContext(state.SetIP(c.UserContext(), ip))
 	return c.Next()
 }
+
+// NewIpAddr reads forwarded client IP headers only from the proxies returned by trusted
+// Requests from any other peer use the connection IP
+func NewIpAddr(trusted func() xip.Networks) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		remote := c.Context().RemoteIP().String()
+		if !trusted().Contains(remote) {
+			c.SetUserContext(state.SetIP(c.UserContext(), remote))
+			return c.Next()
+		}
+		return IpAddr(c)
+	}
+}
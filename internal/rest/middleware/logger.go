@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/state"
+	"github.com/salihguru/idiogo/pkg/xlog"
+)
+
+// NewLogger attaches a request scoped logger to the user context
+// It must run after the RequestID, IpAddr and I18n middlewares to carry their values
+func NewLogger(base *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		l := base.With(
+			slog.String("request_id", state.RequestID(ctx)),
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("ip", state.IP(ctx)),
+			slog.String("locale", state.LocaleStr(ctx)),
+		)
+		c.SetUserContext(xlog.WithLogger(ctx, l))
+		return c.Next()
+	}
+}
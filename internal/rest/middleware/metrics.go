@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/metrics"
+)
+
+// NewMetrics counts requests and their latency by method, route pattern and status in reg
+// Requests are counted once NewErrors has sent the response, see OnDone
+func NewMetrics(reg *metrics.Registry) fiber.Handler {
+	requests := reg.Counter("http_requests_total", "Number of HTTP requests.", "method", "route", "status")
+	latency := reg.Histogram("http_request_duration_seconds", "HTTP request latency in seconds.", metrics.DefBuckets, "method", "route")
+	inFlight := reg.Gauge("http_requests_in_flight", "Number of HTTP requests being served.")
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		inFlight.Add(1)
+		defer inFlight.Add(-1)
+		OnDone(c, func(error) {
+			method, route := c.Method(), c.Route().Path
+			requests.Inc(method, route, strconv.Itoa(c.Response().StatusCode()))
+			latency.Observe(time.Since(start).Seconds(), method, route)
+		})
+		return c.Next()
+	}
+}
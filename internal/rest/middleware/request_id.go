@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salihguru/idiogo/pkg/state"
+)
+
+// maxRequestIDLen bounds accepted incoming request ids
+const maxRequestIDLen = 128
+
+// NewRequestID stores the request id in the user context and echoes it as the X-Request-ID header
+// A valid incoming X-Request-ID is kept, otherwise a new UUID is generated
+func NewRequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if !ValidRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Set(fiber.HeaderXRequestID, id)
+		c.SetUserContext(state.SetRequestID(c.UserContext(), id))
+		return c.Next()
+	}
+}
+
+// ValidRequestID accepts up to maxRequestIDLen letters, digits and -_.: so ids are safe to log and echo
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
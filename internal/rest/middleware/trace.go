@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/salihguru/idiogo/pkg/xtrace"
+)
+
+// NewTrace opens a server span per request continuing the trace of the traceparent header
+// The span context is echoed in the traceparent response header
+// The span ends once NewErrors has sent the response, see OnDone
+// Only server errors mark it as failed, client errors are part of normal operation
+func NewTrace() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := xtrace.Extract(c.UserContext(), func(key string) string { return c.Get(key) })
+		ctx, span := xtrace.Start(ctx, c.Method()+" "+c.Path())
+		if span == nil {
+			return c.Next()
+		}
+		c.SetUserContext(ctx)
+		xtrace.Inject(ctx, c.Set)
+		OnDone(c, func(err error) {
+			status := c.Response().StatusCode()
+			if status >= fiber.StatusInternalServerError {
+				if err == nil {
+					err = errors.New(utils.StatusMessage(status))
+				}
+				span.RecordError(err)
+			}
+			span.SetName(c.Method() + " " + c.Route().Path)
+			span.SetAttr("http.method", c.Method())
+			span.SetAttr("http.route", c.Route().Path)
+			span.SetAttr("http.target", c.OriginalURL())
+			span.SetAttr("http.status_code", status)
+			span.End()
+		})
+		return c.Next()
+	}
+}
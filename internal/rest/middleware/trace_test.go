@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/xtrace"
+)
+
+type memExporter struct {
+	mu    sync.Mutex
+	spans []xtrace.SpanData
+}
+
+func (m *memExporter) Export(s xtrace.SpanData) {
+	m.mu.Lock()
+	m.spans = append(m.spans, s)
+	m.mu.Unlock()
+}
+
+func (m *memExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestTrace(t *testing.T) {
+	exp := &memExporter{}
+	xtrace.SetDefault(xtrace.New(xtrace.Config{SampleRate: 1, Exporter: exp}))
+	t.Cleanup(func() { xtrace.SetDefault(nil) })
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var e *fiber.Error
+			if errors.As(err, &e) {
+				return c.SendStatus(e.Code)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	app.Use(NewErrors(), NewTrace())
+	app.Get("/todos/:id", func(*fiber.Ctx) error { return fiber.ErrNotFound })
+	app.Get("/fail", func(*fiber.Ctx) error { return errors.New("db down") })
+	app.Get("/unavailable", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusServiceUnavailable) })
+
+	tests := []struct {
+		path  string
+		name  string
+		error string
+	}{
+		{"/todos/1", "GET /todos/:id", ""},
+		{"/fail", "GET /fail", "db down"},
+		{"/unavailable", "GET /unavailable", "Service Unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			exp.spans = nil
+			if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil)); err != nil {
+				t.Fatal(err)
+			}
+			if len(exp.spans) != 1 {
+				t.Fatalf("exported %d spans, want 1", len(exp.spans))
+			}
+			span := exp.spans[0]
+			if span.Name != tt.name {
+				t.Errorf("name = %q, want %q", span.Name, tt.name)
+			}
+			if span.Error != tt.error {
+				t.Errorf("error = %q, want %q", span.Error, tt.error)
+			}
+		})
+	}
+}
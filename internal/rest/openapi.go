@@ -0,0 +1,326 @@
+package rest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/openapi"
+	"github.com/salihguru/idiogo/pkg/query"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+)
+
+// OpenAPI document and docs UI paths
+const (
+	PathOpenAPI = "/openapi.json"
+	PathDocs    = "/docs"
+)
+
+// Request sources of an operation, named after the struct tags their parser reads
+const (
+	sourceBody    = "json"
+	sourceQuery   = "query"
+	sourceParams  = "params"
+	sourceHeaders = "reqHeader"
+	sourceCookies = "cookie"
+)
+
+//go:embed docs.html
+var docsHTML string
+
+// operation describes a route declared with RouteBuilder
+type operation struct {
+	name      string
+	request   reflect.Type
+	sources   []string
+	validated bool
+	response  reflect.Type
+	status    int
+	summary   string
+	tags      []string
+}
+
+// routeOps links the operations declared with RouteBuilder to the routes fiber registers, by "METHOD path"
+// A builder declares its operation right before adding the route, the OnRoute hook of the server then takes it
+type routeOps struct {
+	mu   sync.Mutex
+	next *operation
+	ops  map[string]*operation
+}
+
+func newRouteOps() *routeOps {
+	return &routeOps{ops: make(map[string]*operation)}
+}
+
+// declare sets the operation of the route added next
+func (o *routeOps) declare(op *operation) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.next = op
+}
+
+// add links the declared operation to r
+// HEAD routes added for GET routes are skipped so the GET route gets the operation
+func (o *routeOps) add(r fiber.Route) {
+	if r.Method == fiber.MethodHead {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.next != nil {
+		o.ops[r.Method+" "+r.Path] = o.next
+		o.next = nil
+	}
+}
+
+// get returns the operation of a route, nil for routes not built with RouteBuilder
+func (o *routeOps) get(r fiber.Route) *operation {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.ops[r.Method+" "+r.Path]
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// onRoute links the operation declared by a RouteBuilder to the route it registers
+func (s *Server) onRoute(r fiber.Route) error {
+	s.srv.ops.add(r)
+	return nil
+}
+
+// OpenAPI builds the OpenAPI document of every registered route
+func (s *Server) OpenAPI() *openapi.Document {
+	s.register()
+	doc := openapi.New(openapi.Info{
+		Title:   s.cnf.Rest.OpenAPI.Title,
+		Version: s.cnf.Rest.OpenAPI.Version,
+	})
+	addErrorComponents(doc)
+	for _, r := range s.app.GetRoutes(true) {
+		if r.Method == fiber.MethodHead || r.Path == PathOpenAPI || r.Path == PathDocs {
+			continue
+		}
+		doc.AddOperation(r.Method, openAPIPath(r.Path), newOperation(doc, r, s.srv.ops.get(r)))
+	}
+	return doc
+}
+
+// serveOpenAPI answers the document built on the first request
+func (s *Server) serveOpenAPI() fiber.Handler {
+	var (
+		once sync.Once
+		body []byte
+		err  error
+	)
+	return func(c *fiber.Ctx) error {
+		once.Do(func() {
+			body, err = json.Marshal(s.OpenAPI())
+		})
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	}
+}
+
+// serveDocs answers the API reference UI reading PathOpenAPI
+func serveDocs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(docsHTML)
+}
+
+var routeParam = regexp.MustCompile(`:([A-Za-z0-9_]+)\??`)
+
+// openAPIPath turns fiber parameters into OpenAPI ones, e.g. /todos/:id is /todos/{id}
+func openAPIPath(path string) string {
+	return routeParam.ReplaceAllString(path, "{$1}")
+}
+
+func newOperation(doc *openapi.Document, r fiber.Route, op *operation) *openapi.Operation {
+	res := &openapi.Operation{Responses: make(map[string]*openapi.Response)}
+	if tag := pathTag(r.Path); tag != "" {
+		res.Tags = []string{tag}
+	}
+	if op == nil {
+		res.Parameters = pathParams(r.Params, nil)
+		res.Responses[strconv.Itoa(fiber.StatusOK)] = &openapi.Response{Description: http.StatusText(fiber.StatusOK)}
+		return res
+	}
+	res.OperationID = operationID(op.name)
+	res.Summary = op.summary
+	if len(op.tags) > 0 {
+		res.Tags = op.tags
+	}
+	var params []*openapi.Parameter
+	for _, src := range op.sources {
+		switch src {
+		case sourceBody:
+			res.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content:  openapi.JSONContent(doc.BodySchema(op.request, sourceQuery, sourceParams, sourceHeaders, sourceCookies)),
+			}
+		case sourceQuery:
+			params = append(params, doc.Parameters(op.request, openapi.InQuery, sourceQuery)...)
+			if p := filterParameter(op.request); p != nil {
+				params = append(params, p)
+			}
+			describeSort(op.request, params)
+		case sourceParams:
+			params = append(params, doc.Parameters(op.request, openapi.InPath, sourceParams)...)
+		case sourceHeaders:
+			params = append(params, doc.Parameters(op.request, openapi.InHeader, sourceHeaders)...)
+		case sourceCookies:
+			params = append(params, doc.Parameters(op.request, openapi.InCookie, sourceCookies)...)
+		}
+	}
+	res.Parameters = pathParams(r.Params, params)
+
+	success := &openapi.Response{Description: http.StatusText(op.status)}
+	if op.response != nil && op.status != fiber.StatusNoContent {
+		var schema *openapi.Schema
+		if op.response == typeOf[*Response]() {
+			schema = &openapi.Schema{}
+		} else {
+			schema = doc.Schema(op.response)
+		}
+		success.Content = openapi.JSONContent(schema)
+	}
+	res.Responses[strconv.Itoa(op.status)] = success
+	if len(op.sources) > 0 {
+		res.Responses[strconv.Itoa(fiber.StatusBadRequest)] = openapi.ResponseRef(responseBadRequest)
+	}
+	if op.validated {
+		res.Responses[strconv.Itoa(xrescode.ValidationFailedHTTP)] = openapi.ResponseRef(responseValidationFailed)
+	}
+	res.Responses["default"] = openapi.ResponseRef(responseError)
+	return res
+}
+
+// pathParams adds a string parameter for every route parameter params lacks
+func pathParams(names []string, params []*openapi.Parameter) []*openapi.Parameter {
+	for _, name := range names {
+		if slices.ContainsFunc(params, func(p *openapi.Parameter) bool { return p.In == openapi.InPath && p.Name == name }) {
+			continue
+		}
+		params = append(params, &openapi.Parameter{
+			Name:     name,
+			In:       openapi.InPath,
+			Required: true,
+			Schema:   &openapi.Schema{Type: "string"},
+		})
+	}
+	return params
+}
+
+// filterParameter describes the filter[field][op] parameters of requests embedding query.FilterReq
+func filterParameter(t reflect.Type) *openapi.Parameter {
+	f, ok := reflect.New(t).Interface().(query.Filterer)
+	if !ok {
+		return nil
+	}
+	fields := &openapi.Schema{Type: "object", Properties: make(map[string]*openapi.Schema)}
+	for name, ops := range f.FilterSpec().Allowed() {
+		field := &openapi.Schema{Type: "object", Properties: make(map[string]*openapi.Schema, len(ops))}
+		for _, op := range ops {
+			field.Properties[op] = &openapi.Schema{Type: "string"}
+		}
+		fields.Properties[name] = field
+	}
+	explode := true
+	return &openapi.Parameter{
+		Name:        "filter",
+		In:          openapi.InQuery,
+		Description: "Filters written as filter[field][op]=value, in and nin take comma separated values",
+		Style:       "deepObject",
+		Explode:     &explode,
+		Schema:      fields,
+	}
+}
+
+// describeSort lists the sortable fields on the sort parameter of requests embedding query.SortReq
+func describeSort(t reflect.Type, params []*openapi.Parameter) {
+	s, ok := reflect.New(t).Interface().(query.Sorter)
+	if !ok {
+		return
+	}
+	for _, p := range params {
+		if p.In == openapi.InQuery && p.Name == "sort" {
+			p.Description = "Comma separated fields, a leading - sorts in descending order. Fields: " + strings.Join(s.SortSpec().Fields(), ", ")
+		}
+	}
+}
+
+// pathTag groups operations by the first path segment, e.g. todos
+func pathTag(path string) string {
+	seg, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+		return ""
+	}
+	return seg
+}
+
+// operationID turns a handler name like todo.(*Service).Create into todo.Service.Create
+func operationID(name string) string {
+	return strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+}
+
+// Component names of the error responses
+const (
+	responseBadRequest       = "BadRequest"
+	responseValidationFailed = "ValidationFailed"
+	responseError            = "Error"
+	schemaError              = "rest.Error"
+)
+
+// addErrorComponents adds the error bodies of ErrorHandler, the default and the problem+json one
+func addErrorComponents(doc *openapi.Document) {
+	results := xrescode.Results()
+	codes := make([]any, 0, len(results))
+	var desc strings.Builder
+	desc.WriteString("Result codes:\n")
+	for _, r := range results {
+		codes = append(codes, r.Code)
+		fmt.Fprintf(&desc, "- %d %s.%s (HTTP %d)\n", r.Code, r.Domain, r.Key, r.HTTP)
+	}
+	doc.Components.Schemas[schemaError] = &openapi.Schema{
+		Type:     "object",
+		Required: []string{"message"},
+		Properties: map[string]*openapi.Schema{
+			"message":    {Type: "string"},
+			"code":       {Type: "integer", Format: "int64", Enum: codes, Description: desc.String()},
+			"request_id": {Type: "string"},
+			"trace_id":   {Type: "string"},
+			"data":       {},
+		},
+	}
+	problem := doc.Schema(typeOf[Problem]())
+	content := func() map[string]*openapi.MediaType {
+		return map[string]*openapi.MediaType{
+			openapi.ContentTypeJSON: {Schema: openapi.SchemaRef(schemaError)},
+			ContentTypeProblem:      {Schema: problem},
+		}
+	}
+	doc.Components.Responses[responseBadRequest] = &openapi.Response{
+		Description: "The request could not be parsed",
+		Content:     content(),
+	}
+	doc.Components.Responses[responseValidationFailed] = &openapi.Response{
+		Description: "The request is invalid, the field errors are listed in data",
+		Content:     content(),
+	}
+	doc.Components.Responses[responseError] = &openapi.Response{
+		Description: "A result code answered with its HTTP status",
+		Content:     content(),
+	}
+}
@@ -0,0 +1,108 @@
+package rest_test
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/internal/config"
+	"github.com/salihguru/idiogo/internal/domain/todo"
+	"github.com/salihguru/idiogo/internal/port"
+	"github.com/salihguru/idiogo/internal/rest"
+	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/openapi"
+	"github.com/salihguru/idiogo/pkg/validation"
+)
+
+// plainRouter adds a route without RouteBuilder
+type plainRouter struct{}
+
+func (plainRouter) RegisterRoutes(_ port.RestService, router fiber.Router) {
+	router.Get("/health/:probe", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+}
+
+func newTestServer(t *testing.T, routers ...rest.Router) *rest.Server {
+	t.Helper()
+	i18n, err := i18np.New(i18np.ConfigDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.Load("../../assets/locales", "en", "tr")
+	return rest.New(rest.Config{
+		Rest:      config.Rest{OpenAPI: config.OpenAPI{Title: "idiogo API", Version: "1.0.0"}},
+		I18n:      *i18n,
+		Validator: *validation.New(i18n),
+		Routers:   routers,
+		Locales:   []string{"en", "tr"},
+	})
+}
+
+func TestOpenAPITodo(t *testing.T) {
+	tests := []struct {
+		method    string
+		path      string
+		id        string
+		responses []string
+		params    []string
+		body      bool
+	}{
+		{"get", "/todos/", "todo.Service.Find", []string{"200", "400", "422", "default"}, []string{"filter", "limit", "page", "q", "sort", "status"}, false},
+		{"post", "/todos/", "todo.Service.Create", []string{"201", "400", "422", "default"}, nil, true},
+		{"get", "/todos/cursor", "todo.Service.FindByCursor", []string{"200", "400", "422", "default"}, []string{"cursor", "filter", "limit", "q", "status"}, false},
+		{"get", "/todos/{id}", "todo.Service.View", []string{"200", "400", "422", "default"}, []string{"id"}, false},
+		{"patch", "/todos/{id}", "todo.Service.Update", []string{"200", "400", "422", "default"}, []string{"id"}, true},
+		{"delete", "/todos/{id}", "todo.Service.Delete", []string{"204", "400", "422", "default"}, []string{"id"}, false},
+	}
+	doc := newTestServer(t, todo.NewHandler(todo.Service{}), plainRouter{}).OpenAPI()
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			item, ok := doc.Paths[tt.path]
+			if !ok {
+				t.Fatalf("path %s is missing", tt.path)
+			}
+			op, ok := (*item)[tt.method]
+			if !ok {
+				t.Fatalf("operation %s %s is missing", tt.method, tt.path)
+			}
+			if op.OperationID != tt.id {
+				t.Errorf("operationId = %q, want %q", op.OperationID, tt.id)
+			}
+			if got := responses(op); !slices.Equal(got, tt.responses) {
+				t.Errorf("responses = %v, want %v", got, tt.responses)
+			}
+			if got := params(op); !slices.Equal(got, tt.params) {
+				t.Errorf("parameters = %v, want %v", got, tt.params)
+			}
+			if (op.RequestBody != nil) != tt.body {
+				t.Errorf("request body = %v, want %v", op.RequestBody != nil, tt.body)
+			}
+		})
+	}
+
+	op := (*doc.Paths["/health/{probe}"])["get"]
+	if op == nil {
+		t.Fatal("operation get /health/{probe} is missing")
+	}
+	if op.OperationID != "" || !slices.Equal(responses(op), []string{"200"}) || !slices.Equal(params(op), []string{"probe"}) {
+		t.Errorf("a route without RouteBuilder got %+v", op)
+	}
+}
+
+func responses(op *openapi.Operation) []string {
+	res := make([]string, 0, len(op.Responses))
+	for status := range op.Responses {
+		res = append(res, status)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func params(op *openapi.Operation) []string {
+	var res []string
+	for _, p := range op.Parameters {
+		res = append(res, p.Name)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -1,6 +1,13 @@
 package rest
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"errors"
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/query"
+	"github.com/salihguru/idiogo/pkg/xtrace"
+)
 
 type EmptyReq struct{}
 
@@ -8,32 +15,59 @@ type Handler[T any] func(c *fiber.Ctx, payload T) error
 
 func Handle[T any](h Handler[T]) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		ctx, span := xtrace.Start(c.UserContext(), "rest.Handle")
+		defer span.End()
+		c.SetUserContext(ctx)
 		var payload T
-		return h(c, payload)
+		err := h(c, payload)
+		span.RecordError(err)
+		return err
 	}
 }
 
 func WithBody[T any](h Handler[T]) Handler[T] {
 	return func(c *fiber.Ctx, payload T) error {
-		if err := c.BodyParser(&payload); err != nil {
+		if err := badRequest(traced(c, "rest.WithBody", func() error { return c.BodyParser(&payload) })); err != nil {
 			return err
 		}
 		return h(c, payload)
 	}
 }
 
+// filterSetter is implemented by requests embedding query.FilterReq
+type filterSetter interface {
+	SetFilter(f query.Filter)
+}
+
+// WithQuery binds the query string, and the filter[field][op] parameters of requests embedding query.FilterReq
 func WithQuery[T any](h Handler[T]) Handler[T] {
 	return func(c *fiber.Ctx, payload T) error {
-		if err := c.QueryParser(&payload); err != nil {
+		if err := badRequest(traced(c, "rest.WithQuery", func() error { return bindQuery(c, &payload) })); err != nil {
 			return err
 		}
 		return h(c, payload)
 	}
 }
 
+func bindQuery(c *fiber.Ctx, payload any) error {
+	if err := c.QueryParser(payload); err != nil {
+		return err
+	}
+	f, ok := payload.(filterSetter)
+	if !ok {
+		return nil
+	}
+	values, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return err
+	}
+	f.SetFilter(query.ParseFilter(values))
+	return nil
+}
+
 func WithParams[T any](h Handler[T]) Handler[T] {
 	return func(c *fiber.Ctx, payload T) error {
-		if err := c.ParamsParser(&payload); err != nil {
+		if err := badRequest(traced(c, "rest.WithParams", func() error { return c.ParamsParser(&payload) })); err != nil {
 			return err
 		}
 		return h(c, payload)
@@ -42,7 +76,7 @@ func WithParams[T any](h Handler[T]) Handler[T] {
 
 func WithHeaders[T any](h Handler[T]) Handler[T] {
 	return func(c *fiber.Ctx, payload T) error {
-		if err := c.ReqHeaderParser(&payload); err != nil {
+		if err := badRequest(traced(c, "rest.WithHeaders", func() error { return c.ReqHeaderParser(&payload) })); err != nil {
 			return err
 		}
 		return h(c, payload)
@@ -51,7 +85,7 @@ func WithHeaders[T any](h Handler[T]) Handler[T] {
 
 func WithCookies[T any](h Handler[T]) Handler[T] {
 	return func(c *fiber.Ctx, payload T) error {
-		if err := c.CookieParser(&payload); err != nil {
+		if err := badRequest(traced(c, "rest.WithCookies", func() error { return c.CookieParser(&payload) })); err != nil {
 			return err
 		}
 		return h(c, payload)
@@ -60,9 +94,37 @@ func WithCookies[T any](h Handler[T]) Handler[T] {
 
 func WithValidation[T any](fn ValidatorFn, h Handler[T]) Handler[T] {
 	return func(c *fiber.Ctx, payload T) error {
-		if err := fn(c.UserContext(), &payload); err != nil {
+		if err := traced(c, "rest.WithValidation", func() error { return fn(c.UserContext(), &payload) }); err != nil {
 			return err
 		}
 		return h(c, payload)
 	}
 }
+
+// badRequest turns parser errors that are not fiber errors, e.g. malformed JSON, into 400 errors
+// The parser error is recorded on the span only, its message may expose decoder internals
+func badRequest(err error) error {
+	if err == nil {
+		return nil
+	}
+	var ferr *fiber.Error
+	if errors.As(err, &ferr) {
+		return err
+	}
+	return fiber.ErrBadRequest
+}
+
+// traced runs fn in a span that ends before the next handler starts
+func traced(c *fiber.Ctx, name string, fn func() error) error {
+	ctx, span := xtrace.Start(c.UserContext(), name)
+	defer span.End()
+	if span == nil {
+		return fn()
+	}
+	parent := c.UserContext()
+	c.SetUserContext(ctx)
+	err := fn()
+	c.SetUserContext(parent)
+	span.RecordError(err)
+	return err
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/restayway/rescode"
+	"github.com/salihguru/idiogo/pkg/state"
+	"github.com/salihguru/idiogo/pkg/validation"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+	"github.com/salihguru/idiogo/pkg/xtrace"
+)
+
+// ContentTypeProblem is the media type of RFC 9457 problem details
+const ContentTypeProblem = "application/problem+json"
+
+// Problem is an RFC 9457 problem details object
+// Code, RequestID, TraceID, Errors and Data are extension members
+type Problem struct {
+	Type      string                      `json:"type"`
+	Title     string                      `json:"title"`
+	Status    int                         `json:"status"`
+	Detail    string                      `json:"detail,omitempty"`
+	Instance  string                      `json:"instance,omitempty"`
+	Code      uint64                      `json:"code,omitempty"`
+	RequestID string                      `json:"request_id,omitempty"`
+	TraceID   string                      `json:"trace_id,omitempty"`
+	Errors    []*validation.ErrorResponse `json:"errors,omitempty"`
+	Data      any                         `json:"data,omitempty"`
+}
+
+// problemType is the type URI of a result code, about:blank when no base is configured
+func problemType(base string, code uint64) string {
+	if base == "" || code == 0 {
+		return "about:blank"
+	}
+	return base + strconv.FormatUint(code, 10)
+}
+
+// newProblem maps err to a problem
+// Errors that are neither result codes nor fiber errors become a 500 failed problem
+func (s Service) newProblem(c *fiber.Ctx, err error) Problem {
+	ctx := c.UserContext()
+	p := Problem{
+		Instance:  c.Path(),
+		RequestID: state.RequestID(ctx),
+		TraceID:   xtrace.TraceIDFrom(ctx),
+	}
+	var (
+		res  *rescode.RC
+		ferr *fiber.Error
+	)
+	switch {
+	case errors.As(err, &res):
+		p.Status = res.HttpCode
+		p.Code = res.Code
+		p.Detail = s.i18n.Translate(res.Message, state.LocaleStr(ctx))
+		if errs, ok := res.Data.([]*validation.ErrorResponse); ok {
+			p.Errors = errs
+		} else {
+			p.Data = res.Data
+		}
+	case errors.As(err, &ferr):
+		p.Status = ferr.Code
+		p.Detail = ferr.Message
+	default:
+		p.Status = xrescode.FailedHTTP
+		p.Code = xrescode.FailedCode
+		p.Detail = s.i18n.Translate(xrescode.FailedMsg, state.LocaleStr(ctx))
+	}
+	p.Type = problemType(s.settings.Load().ProblemTypeBase, p.Code)
+	p.Title = http.StatusText(p.Status)
+	return p
+}
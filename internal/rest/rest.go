@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"slices"
+	"sync"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/salihguru/idiogo/internal/config"
 	"github.com/salihguru/idiogo/internal/port"
+	"github.com/salihguru/idiogo/internal/rest/middleware"
 	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/metrics"
 	"github.com/salihguru/idiogo/pkg/validation"
 	"github.com/salihguru/idiogo/pkg/xascii"
 	"github.com/salihguru/idiogo/pkg/xip"
@@ -28,9 +32,10 @@ type Router interface {
 }
 
 type Server struct {
-	app *fiber.App
-	cnf Config
-	srv Service
+	app   *fiber.App
+	cnf   Config
+	srv   Service
+	setup sync.Once
 }
 
 type Config struct {
@@ -39,17 +44,22 @@ type Config struct {
 	Validator validation.Srv
 	Routers   []Router
 	Locales   []string
+	Logger    *slog.Logger
+
+	// DefaultLocale is used for requests accepting none of the Locales, en when empty
+	DefaultLocale string
+
+	Metrics *metrics.Registry
 }
 
 func New(cnf Config) *Server {
-	srv := Service{
-		i18n:      cnf.I18n,
-		validator: cnf.Validator,
-		locales:   cnf.Locales,
+	srv := NewService(cnf.I18n, cnf.Validator, cnf.Locales)
+	if cnf.Logger != nil {
+		srv.logger = cnf.Logger
 	}
-	return &Server{
+	s := &Server{
 		cnf: cnf,
-		srv: srv,
+		srv: *srv,
 		app: fiber.New(fiber.Config{
 			ErrorHandler:            srv.ErrorHandler(),
 			DisableStartupMessage:   true,
@@ -62,18 +72,73 @@ func New(cnf Config) *Server {
 			ReadBufferSize:          100 * 1024 * 1024,
 			ProxyHeader:             fiber.HeaderXForwardedFor,
 			EnableTrustedProxyCheck: true,
-			TrustedProxies:          slices.Concat(xip.LocalIPs, xip.CloudflareIPv4, xip.CloudflareIPv6),
+			TrustedProxies:          DefaultTrustedProxies,
 		}),
 	}
+	s.app.Hooks().OnRoute(s.onRoute)
+	if err := s.Reload(cnf.Rest, cnf.Locales, cnf.DefaultLocale); err != nil {
+		slog.Error("rest: invalid trusted proxies, using defaults", "error", err)
+	}
+	return s
 }
 
-func (s *Server) Listen() error {
-	s.app.Use(s.srv.Recover(), s.srv.I18n(), s.srv.IpAddr())
-	for _, r := range s.cnf.Routers {
-		r.RegisterRoutes(s.srv, s.app)
+// Reload applies the hot reloadable REST settings
+// Host and port changes need a restart and are ignored here
+func (s *Server) Reload(cnf config.Rest, locales []string, defaultLocale string) error {
+	proxies := cnf.TrustedProxies
+	if len(proxies) == 0 {
+		proxies = DefaultTrustedProxies
 	}
+	trusted, err := xip.ParseNetworks(proxies)
+	if err != nil {
+		return err
+	}
+	s.srv.SetSettings(Settings{
+		Locales:        slices.Clone(locales),
+		DefaultLocale:  defaultLocale,
+		RateLimit:      cnf.RateLimit,
+		TrustedProxies: trusted,
+		AccessLog: middleware.AccessLogConfig{
+			Enabled:    cnf.AccessLog.Enabled,
+			SampleRate: cnf.AccessLog.SampleRate,
+			Exclude:    slices.Clone(cnf.AccessLog.Exclude),
+		},
+		ProblemJSON:     cnf.ProblemJSON,
+		ProblemTypeBase: cnf.ProblemTypeBase,
+	})
+	return nil
+}
+
+// register mounts the middlewares and every router exactly once
+func (s *Server) register() {
+	s.setup.Do(func() {
+		s.app.Use(s.srv.Errors(), s.srv.Recover(), s.srv.RequestID(), middleware.NewTrace(), s.srv.I18n(), s.srv.IpAddr(), s.srv.Logger())
+		if s.cnf.Metrics != nil {
+			s.app.Use(middleware.NewMetrics(s.cnf.Metrics))
+		}
+		s.app.Use(s.srv.serverAccessLog())
+		for _, r := range s.cnf.Routers {
+			r.RegisterRoutes(s.srv, s.app)
+		}
+		if s.cnf.Rest.OpenAPI.Enabled || s.cnf.Rest.OpenAPI.Docs {
+			s.app.Get(PathOpenAPI, s.serveOpenAPI())
+		}
+		if s.cnf.Rest.OpenAPI.Docs {
+			s.app.Get(PathDocs, serveDocs)
+		}
+	})
+}
+
+// Routes returns every registered route without starting the server
+func (s *Server) Routes() []fiber.Route {
+	s.register()
+	return s.app.GetRoutes(true)
+}
+
+func (s *Server) Listen() error {
+	s.register()
 	xascii.Log()
-	fmt.Printf("idiogo api is running on %s:%s\n", s.cnf.Rest.Host, s.cnf.Rest.Port)
+	slog.Info("idiogo api is running", "host", s.cnf.Rest.Host, "port", s.cnf.Rest.Port)
 	return s.app.Listen(fmt.Sprintf(":%v", s.cnf.Rest.Port))
 }
 
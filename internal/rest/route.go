@@ -0,0 +1,225 @@
+package rest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/timeout"
+	"github.com/salihguru/idiogo/internal/port"
+)
+
+// DefaultTimeout bounds the handlers of Service.Timeout and of routes built with Route
+const DefaultTimeout = 50 * time.Second
+
+// RouteBuilder declares a route whose input I is bound from the request and whose output O is answered as JSON
+// The sources are always bound in the same order: body, cookies, headers, query and params,
+// so a route parameter can't be overridden by the body, then the input is validated when it is a struct
+type RouteBuilder[I any, O any] struct {
+	srv     port.RestService
+	router  fiber.Router
+	method  string
+	path    string
+	sources []string
+	timeout time.Duration
+	status  int
+	summary string
+	tags    []string
+	mws     []fiber.Handler
+}
+
+// Route starts a route on router, the handlers of I and O are set with Handle or Exec
+// example:
+//
+//	rest.Route[todo.UpdateReq, *todo.Todo](srv, group).Patch("/:id").Params().Body().Handle(h.srv.Update)
+func Route[I any, O any](srv port.RestService, router fiber.Router) *RouteBuilder[I, O] {
+	return &RouteBuilder[I, O]{srv: srv, router: router, timeout: DefaultTimeout}
+}
+
+func (r *RouteBuilder[I, O]) Get(path string) *RouteBuilder[I, O] {
+	return r.at(fiber.MethodGet, path)
+}
+
+func (r *RouteBuilder[I, O]) Post(path string) *RouteBuilder[I, O] {
+	return r.at(fiber.MethodPost, path)
+}
+
+func (r *RouteBuilder[I, O]) Put(path string) *RouteBuilder[I, O] {
+	return r.at(fiber.MethodPut, path)
+}
+
+func (r *RouteBuilder[I, O]) Patch(path string) *RouteBuilder[I, O] {
+	return r.at(fiber.MethodPatch, path)
+}
+
+func (r *RouteBuilder[I, O]) Delete(path string) *RouteBuilder[I, O] {
+	return r.at(fiber.MethodDelete, path)
+}
+
+func (r *RouteBuilder[I, O]) at(method, path string) *RouteBuilder[I, O] {
+	r.method, r.path = method, path
+	return r
+}
+
+// Params binds the route parameters into the params tagged fields of I
+func (r *RouteBuilder[I, O]) Params() *RouteBuilder[I, O] {
+	return r.from(sourceParams)
+}
+
+// Query binds the query string into the query tagged fields of I
+func (r *RouteBuilder[I, O]) Query() *RouteBuilder[I, O] {
+	return r.from(sourceQuery)
+}
+
+// Headers binds the request headers into the reqHeader tagged fields of I
+func (r *RouteBuilder[I, O]) Headers() *RouteBuilder[I, O] {
+	return r.from(sourceHeaders)
+}
+
+// Cookies binds the cookies into the cookie tagged fields of I
+func (r *RouteBuilder[I, O]) Cookies() *RouteBuilder[I, O] {
+	return r.from(sourceCookies)
+}
+
+// Body binds the request body into I
+func (r *RouteBuilder[I, O]) Body() *RouteBuilder[I, O] {
+	return r.from(sourceBody)
+}
+
+func (r *RouteBuilder[I, O]) from(source string) *RouteBuilder[I, O] {
+	if !slices.Contains(r.sources, source) {
+		r.sources = append(r.sources, source)
+	}
+	return r
+}
+
+// Timeout bounds the handler to d instead of DefaultTimeout, 0 disables the timeout
+func (r *RouteBuilder[I, O]) Timeout(d time.Duration) *RouteBuilder[I, O] {
+	r.timeout = d
+	return r
+}
+
+// Status sets the success status, by default 201 for POST routes and 200 for the others with Handle, 204 with Exec
+func (r *RouteBuilder[I, O]) Status(code int) *RouteBuilder[I, O] {
+	r.status = code
+	return r
+}
+
+// Use runs the middlewares before binding, e.g. srv.RateLimit(10)
+func (r *RouteBuilder[I, O]) Use(mws ...fiber.Handler) *RouteBuilder[I, O] {
+	r.mws = append(r.mws, mws...)
+	return r
+}
+
+// Summary describes the route in the OpenAPI document
+func (r *RouteBuilder[I, O]) Summary(summary string) *RouteBuilder[I, O] {
+	r.summary = summary
+	return r
+}
+
+// Tags groups the route in the OpenAPI document, the first path segment by default
+func (r *RouteBuilder[I, O]) Tags(tags ...string) *RouteBuilder[I, O] {
+	r.tags = append(r.tags, tags...)
+	return r
+}
+
+// Handle registers the route answering the output of h
+// A *Response output sets its headers, cookies and status like rest.Data
+func (r *RouteBuilder[I, O]) Handle(h HandlerWithRes[I, O]) {
+	status := r.status
+	if status == 0 {
+		status = fiber.StatusOK
+		if r.method == fiber.MethodPost {
+			status = fiber.StatusCreated
+		}
+	}
+	name := funcName(h)
+	r.register(name, status, typeOf[O](), func(fctx *fiber.Ctx, payload I) error {
+		res, err := callWithRes(fctx, name, h, payload)
+		if err != nil {
+			return err
+		}
+		return respond(fctx, res, status)
+	})
+}
+
+// Exec registers the route answering only a status, 204 unless Status is set
+func (r *RouteBuilder[I, O]) Exec(h ReqHandler[I]) {
+	status := r.status
+	if status == 0 {
+		status = fiber.StatusNoContent
+	}
+	name := funcName(h)
+	r.register(name, status, nil, func(fctx *fiber.Ctx, payload I) error {
+		if err := call(fctx, name, h, payload); err != nil {
+			return err
+		}
+		return fctx.SendStatus(status)
+	})
+}
+
+// declarer is implemented by Service, it records the operation of the route added next for the OpenAPI document
+type declarer interface {
+	declare(op *operation)
+}
+
+func (r *RouteBuilder[I, O]) register(name string, status int, response reflect.Type, h Handler[I]) {
+	if r.method == "" {
+		panic(fmt.Sprintf("rest: route %s has no method, call Get, Post, Put, Patch or Delete first", name))
+	}
+	validated := isStruct(typeOf[I]())
+	if validated {
+		h = WithValidation(r.srv.ValidateStruct(), h)
+	}
+	// wrap in reverse so the sources are bound in the documented order
+	for _, src := range []string{sourceParams, sourceQuery, sourceHeaders, sourceCookies, sourceBody} {
+		if !slices.Contains(r.sources, src) {
+			continue
+		}
+		switch src {
+		case sourceBody:
+			h = WithBody(h)
+		case sourceCookies:
+			h = WithCookies(h)
+		case sourceHeaders:
+			h = WithHeaders(h)
+		case sourceQuery:
+			h = WithQuery(h)
+		case sourceParams:
+			h = WithParams(h)
+		}
+	}
+	handler := Handle(h)
+	if r.timeout > 0 {
+		handler = timeout.NewWithContext(handler, r.timeout)
+	}
+	if d, ok := r.srv.(declarer); ok {
+		d.declare(&operation{
+			name:      name,
+			request:   typeOf[I](),
+			sources:   r.sources,
+			validated: validated,
+			response:  response,
+			status:    status,
+			summary:   r.summary,
+			tags:      r.tags,
+		})
+	}
+	handlers := append(slices.Clone(r.mws), handler)
+	if r.method == fiber.MethodGet {
+		// Get also answers HEAD requests
+		r.router.Get(r.path, handlers...)
+		return
+	}
+	r.router.Add(r.method, r.path, handlers...)
+}
+
+// isStruct reports whether t is a struct or a pointer to one, the inputs validation.Srv accepts
+func isStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRouteParamsWin(t *testing.T) {
+	type updateReq struct {
+		ID    string `params:"id"`
+		Title string `json:"title"`
+	}
+	srv := newTestService(t)
+	app := fiber.New(fiber.Config{ErrorHandler: srv.ErrorHandler()})
+	Route[updateReq, updateReq](srv, app).Patch("/todos/:id").Params().Body().
+		Handle(func(_ context.Context, req updateReq) (updateReq, error) { return req, nil })
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1", strings.NewReader(`{"id":"2","ID":"3","title":"buy milk"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", res.StatusCode, fiber.StatusOK, body)
+	}
+	if !jsonEqual(t, body, `{"ID":"1","title":"buy milk"}`) {
+		t.Errorf("body = %s, want the id of the path", body)
+	}
+}
@@ -2,7 +2,11 @@ package rest
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,53 +18,174 @@ import (
 	"github.com/salihguru/idiogo/pkg/i18np"
 	"github.com/salihguru/idiogo/pkg/state"
 	"github.com/salihguru/idiogo/pkg/validation"
+	"github.com/salihguru/idiogo/pkg/xip"
+	"github.com/salihguru/idiogo/pkg/xlog"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+	"github.com/salihguru/idiogo/pkg/xtrace"
 )
 
+// DefaultTrustedProxies are trusted to set forwarded client IP headers when no proxies are configured
+var DefaultTrustedProxies = slices.Concat(xip.LocalIPs, xip.CloudflareIPv4, xip.CloudflareIPv6)
+
+// DefaultRateLimit is used by RateLimit(0) until the settings are loaded from the config
+const DefaultRateLimit = 100
+
+// Settings are the REST options that can change while the server is running
+type Settings struct {
+	Locales        []string
+	RateLimit      int
+	TrustedProxies xip.Networks
+	AccessLog      middleware.AccessLogConfig
+
+	// DefaultLocale is used for requests accepting none of the Locales
+	DefaultLocale string
+
+	// ProblemJSON answers errors as RFC 9457 application/problem+json
+	ProblemJSON bool
+
+	// ProblemTypeBase prefixes the result code to build the problem type URI
+	ProblemTypeBase string
+}
+
 type Service struct {
 	i18n      i18np.I18n
 	validator validation.Srv
-	locales   []string
+	settings  *atomic.Pointer[Settings]
+	logger    *slog.Logger
+
+	// ops are the operations of the routes built with RouteBuilder, see Server.OpenAPI
+	ops *routeOps
 }
 
 func NewService(i18n i18np.I18n, validator validation.Srv, locales []string) *Service {
-	return &Service{
+	srv := &Service{
 		i18n:      i18n,
 		validator: validator,
-		locales:   locales,
+		settings:  &atomic.Pointer[Settings]{},
+		logger:    slog.Default(),
+		ops:       newRouteOps(),
 	}
+	trusted, _ := xip.ParseNetworks(DefaultTrustedProxies)
+	srv.settings.Store(&Settings{Locales: locales, RateLimit: DefaultRateLimit, TrustedProxies: trusted})
+	return srv
+}
+
+// Settings returns the running settings
+func (s Service) Settings() Settings {
+	return *s.settings.Load()
+}
+
+// SetSettings swaps the running settings, requests already in flight keep the old ones
+func (s Service) SetSettings(st Settings) {
+	s.settings.Store(&st)
+}
+
+// declare records op for the route RouteBuilder adds next, see Server.OpenAPI
+func (s Service) declare(op *operation) {
+	s.ops.declare(op)
 }
 
 func (s Service) ValidateStruct() func(ctx context.Context, sc interface{}) error {
 	return s.validator.ValidateStruct
 }
 
+// ErrorHandler answers result codes with their status and fiber errors with their code
+// Other errors keep the empty 400 response, problem+json answers them as a 500 failed problem
 func (s Service) ErrorHandler() fiber.ErrorHandler {
 	return func(c *fiber.Ctx, err error) error {
-		code := fiber.StatusBadRequest
-		if res, ok := err.(*rescode.RC); ok {
-			res.Message = s.i18n.Translate(res.Message, state.LocaleStr(c.UserContext()))
-			if res.Data != nil {
-				return c.Status(res.HttpCode).JSON(res.JSON())
+		err = xrescode.Classify(err)
+		if s.settings.Load().ProblemJSON {
+			p := s.newProblem(c, err)
+			if p.Status >= fiber.StatusInternalServerError {
+				xlog.From(c.UserContext()).ErrorContext(c.UserContext(), "request failed", "error", err)
+			}
+			c.Set(fiber.HeaderContentType, ContentTypeProblem)
+			body, mErr := c.App().Config().JSONEncoder(p)
+			if mErr != nil {
+				return mErr
+			}
+			return c.Status(p.Status).Send(body)
+		}
+		var res *rescode.RC
+		if errors.As(err, &res) {
+			if res.HttpCode >= fiber.StatusInternalServerError {
+				xlog.From(c.UserContext()).ErrorContext(c.UserContext(), "request failed", "error", err)
 			}
-			return c.Status(res.HttpCode).JSON(fiber.Map{
+			res.Message = s.i18n.Translate(res.Message, state.LocaleStr(c.UserContext()))
+			body := fiber.Map{
 				"message": res.Message,
 				"code":    res.Code,
-			})
+			}
+			if res.Data != nil {
+				// the wrapped original error is left out, it may expose database details
+				body = res.JSON("code", "message", "httpCode", "rpcCode", "data")
+			}
+			return c.Status(res.HttpCode).JSON(withCorrelation(c.UserContext(), body))
 		}
-		if e, ok := err.(*fiber.Error); ok {
-			code = e.Code
+		var e *fiber.Error
+		if errors.As(err, &e) {
+			return c.Status(e.Code).JSON(withCorrelation(c.UserContext(), fiber.Map{}))
 		}
-		fmt.Println(err)
-		return c.Status(code).JSON(map[string]interface{}{})
+		xlog.From(c.UserContext()).ErrorContext(c.UserContext(), "request failed", "error", err)
+		return c.Status(fiber.StatusBadRequest).JSON(withCorrelation(c.UserContext(), fiber.Map{}))
 	}
 }
 
+// withCorrelation adds the request and trace ids the client can quote to support
+func withCorrelation(ctx context.Context, body fiber.Map) fiber.Map {
+	if id := state.RequestID(ctx); id != "" {
+		body["request_id"] = id
+	}
+	if id := xtrace.TraceIDFrom(ctx); id != "" {
+		body["trace_id"] = id
+	}
+	return body
+}
+
+// Errors answers the errors of every handler with ErrorHandler in one place
+// It is mounted first so the other middlewares see the response that is sent, see middleware.OnDone
+func (s Service) Errors() fiber.Handler {
+	return middleware.NewErrors()
+}
+
+// RequestID keeps or generates the X-Request-ID of every request, see state.RequestID
+func (s Service) RequestID() fiber.Handler {
+	return middleware.NewRequestID()
+}
+
 func (s Service) IpAddr() fiber.Handler {
-	return middleware.IpAddr
+	return middleware.NewIpAddr(func() xip.Networks {
+		return s.settings.Load().TrustedProxies
+	})
 }
 
 func (s Service) I18n() fiber.Handler {
-	return middleware.NewI18n(s.locales)
+	return middleware.NewDynamicI18n(func() middleware.I18nConfig {
+		settings := s.settings.Load()
+		return middleware.I18nConfig{Locales: settings.Locales, Default: settings.DefaultLocale}
+	})
+}
+
+// Logger attaches the request scoped logger, see xlog.From
+func (s Service) Logger() fiber.Handler {
+	return middleware.NewLogger(s.logger)
+}
+
+// AccessLog logs the requests of the routes it is mounted on even if the server access log is disabled
+// Sampling and exclusions follow the configured access log
+func (s Service) AccessLog() fiber.Handler {
+	return middleware.NewAccessLog(s.logger, func() middleware.AccessLogConfig {
+		cnf := s.settings.Load().AccessLog
+		cnf.Enabled = true
+		return cnf
+	})
+}
+
+// serverAccessLog logs every request when the access log is enabled
+func (s Service) serverAccessLog() fiber.Handler {
+	return middleware.NewAccessLog(s.logger, func() middleware.AccessLogConfig {
+		return s.settings.Load().AccessLog
+	})
 }
 
 func (s Service) Recover() fiber.Handler {
@@ -69,13 +194,34 @@ func (s Service) Recover() fiber.Handler {
 	})
 }
 
+// RateLimit limits every client to limit requests in 10 minutes
+// A limit of 0 follows the configured rate limit, also when it changes at runtime
+// A configured rate limit of 0 turns these routes' limit off
 func (h Service) RateLimit(limit int) fiber.Handler {
+	if limit > 0 {
+		return newLimiter(limit)
+	}
+	var limiters sync.Map
+	return func(c *fiber.Ctx) error {
+		max := h.settings.Load().RateLimit
+		if max <= 0 {
+			return c.Next()
+		}
+		l, ok := limiters.Load(max)
+		if !ok {
+			l, _ = limiters.LoadOrStore(max, newLimiter(max))
+		}
+		return l.(fiber.Handler)(c)
+	}
+}
+
+func newLimiter(max int) fiber.Handler {
 	return limiter.New(limiter.Config{
-		Max:        limit,
+		Max:        max,
 		Expiration: 10 * time.Minute,
 	})
 }
 
 func (h Service) Timeout(fn fiber.Handler) fiber.Handler {
-	return timeout.NewWithContext(fn, 50*time.Second)
+	return timeout.NewWithContext(fn, DefaultTimeout)
 }
@@ -0,0 +1,197 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/salihguru/idiogo/pkg/i18np"
+	"github.com/salihguru/idiogo/pkg/state"
+	"github.com/salihguru/idiogo/pkg/validation"
+	"github.com/salihguru/idiogo/pkg/xrescode"
+)
+
+// newTestService returns a service translating with the bundled locales
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	i18n, err := i18np.New(i18np.ConfigDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.Load("../../assets/locales", "en", "tr")
+	return NewService(*i18n, *validation.New(i18n), []string{"en", "tr"})
+}
+
+func TestNewProblem(t *testing.T) {
+	fields := []*validation.ErrorResponse{{Field: "Title", Namespace: "Title", Message: "title is required"}}
+	invalid := xrescode.ValidationFailed(errors.New("title"))
+	invalid.Data = fields
+	notFound := xrescode.NotFound(errors.New("record not found"))
+	notFound.Data = map[string]any{"id": "1"}
+
+	tests := []struct {
+		name string
+		err  error
+		base string
+		want Problem
+	}{
+		{
+			name: "result code with validation data",
+			err:  invalid,
+			base: "https://docs.example.com/errors/",
+			want: Problem{
+				Type:   "https://docs.example.com/errors/1000",
+				Title:  "Unprocessable Entity",
+				Status: 422,
+				Detail: "The request is invalid.",
+				Code:   xrescode.ValidationFailedCode,
+				Errors: fields,
+			},
+		},
+		{
+			name: "result code with other data",
+			err:  notFound,
+			want: Problem{
+				Type:   "about:blank",
+				Title:  "Not Found",
+				Status: 404,
+				Detail: "The resource is not found.",
+				Code:   notFound.Code,
+				Data:   map[string]any{"id": "1"},
+			},
+		},
+		{
+			name: "fiber error",
+			err:  fiber.NewError(fiber.StatusTeapot, "short and stout"),
+			base: "https://docs.example.com/errors/",
+			want: Problem{Type: "about:blank", Title: "I'm a teapot", Status: 418, Detail: "short and stout"},
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("pq: connection refused"),
+			want: Problem{
+				Type:   "about:blank",
+				Title:  "Internal Server Error",
+				Status: 500,
+				Detail: "Something went wrong, please try again later.",
+				Code:   xrescode.FailedCode,
+			},
+		},
+	}
+	srv := newTestService(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := srv.Settings()
+			st.ProblemTypeBase = tt.base
+			srv.SetSettings(st)
+			var got Problem
+			app := fiber.New()
+			app.Get("/todos", func(c *fiber.Ctx) error {
+				c.SetUserContext(state.SetRequestID(state.SetLocale(c.UserContext(), "en"), "req-1"))
+				got = srv.newProblem(c, tt.err)
+				return nil
+			})
+			if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/todos", nil)); err != nil {
+				t.Fatal(err)
+			}
+			tt.want.Instance = "/todos"
+			tt.want.RequestID = "req-1"
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newProblem() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		problem bool
+		handler fiber.Handler
+		status  int
+		body    string
+	}{
+		{
+			name:    "result code",
+			handler: func(*fiber.Ctx) error { return xrescode.NotFound() },
+			status:  404,
+			body:    `{"code":1002,"message":"The resource is not found.","request_id":"req-1"}`,
+		},
+		{
+			name:    "fiber error",
+			handler: func(*fiber.Ctx) error { return fiber.ErrTeapot },
+			status:  418,
+			body:    `{"request_id":"req-1"}`,
+		},
+		{
+			name:    "unknown error",
+			handler: func(*fiber.Ctx) error { return errors.New("pq: connection refused") },
+			status:  400,
+			body:    `{"request_id":"req-1"}`,
+		},
+		{
+			name:    "malformed body",
+			handler: Handle(WithBody(func(*fiber.Ctx, struct{ Title string }) error { return nil })),
+			status:  400,
+			body:    `{"request_id":"req-1"}`,
+		},
+		{
+			name:    "problem malformed body",
+			problem: true,
+			handler: Handle(WithBody(func(*fiber.Ctx, struct{ Title string }) error { return nil })),
+			status:  400,
+			body:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Bad Request","instance":"/","request_id":"req-1"}`,
+		},
+		{
+			name:    "problem unknown error",
+			problem: true,
+			handler: func(*fiber.Ctx) error { return errors.New("pq: connection refused") },
+			status:  500,
+			body: `{"type":"about:blank","title":"Internal Server Error","status":500,` +
+				`"detail":"Something went wrong, please try again later.","instance":"/","code":1001,"request_id":"req-1"}`,
+		},
+	}
+	srv := newTestService(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := srv.Settings()
+			st.ProblemJSON = tt.problem
+			srv.SetSettings(st)
+			app := fiber.New(fiber.Config{ErrorHandler: srv.ErrorHandler()})
+			app.Use(srv.Errors(), srv.RequestID(), srv.I18n())
+			app.Post("/", tt.handler)
+			req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(`{"title":`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			req.Header.Set(fiber.HeaderXRequestID, "req-1")
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.status)
+			}
+			if !jsonEqual(t, body, tt.body) {
+				t.Errorf("body = %s, want %s", body, tt.body)
+			}
+		})
+	}
+}
+
+// jsonEqual compares two JSON documents ignoring key order
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	return reflect.DeepEqual(g, w)
+}
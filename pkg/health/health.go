@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// DefaultTimeout bounds a single check when no timeout is given to New
+const DefaultTimeout = 2 * time.Second
+
+// CheckFunc reports the health of a dependency, a nil error means healthy
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of a single named check
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of every registered check
+type Report struct {
+	Status    string   `json:"status"`
+	Ready     bool     `json:"ready"`
+	Draining  bool     `json:"draining"`
+	LatencyMs float64  `json:"latency_ms"`
+	Checks    []Result `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs the named dependency checks and tracks readiness
+type Checker struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	checks   []check
+	draining atomic.Bool
+}
+
+// New creates a checker, each check gets at most timeout to complete
+func New(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a named check, registering the same name again replaces it
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, ch := range c.checks {
+		if ch.name == name {
+			c.checks[i].fn = fn
+			return
+		}
+	}
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Drain marks the application as not ready, e.g. when shutdown starts
+// It cannot be undone
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Draining reports whether Drain was called
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Check runs every registered check concurrently
+// The report is ready only if every check is up and the checker is not draining
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]check{}, c.checks...)
+	c.mu.RUnlock()
+
+	start := time.Now()
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusUp,
+		Draining:  c.Draining(),
+		LatencyMs: millis(time.Since(start)),
+		Checks:    results,
+	}
+	for _, r := range results {
+		if r.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	report.Ready = report.Status == StatusUp && !report.Draining
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, ch check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	start := time.Now()
+	err := ch.fn(ctx)
+	res := Result{Name: ch.name, Status: StatusUp, LatencyMs: millis(time.Since(start))}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
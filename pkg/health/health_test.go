@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("all checks up", func(t *testing.T) {
+		c := New(time.Second)
+		c.Register("db", func(ctx context.Context) error { return nil })
+		c.Register("cache", func(ctx context.Context) error { return nil })
+
+		report := c.Check(context.Background())
+
+		if !report.Ready || report.Status != StatusUp {
+			t.Errorf("Check() = %+v, want ready", report)
+		}
+		if len(report.Checks) != 2 || report.Checks[0].Name != "db" {
+			t.Errorf("Check() checks = %+v, want db and cache in order", report.Checks)
+		}
+	})
+
+	t.Run("failing check", func(t *testing.T) {
+		c := New(time.Second)
+		c.Register("db", func(ctx context.Context) error { return errors.New("connection refused") })
+
+		report := c.Check(context.Background())
+
+		if report.Ready || report.Status != StatusDown {
+			t.Errorf("Check() = %+v, want not ready", report)
+		}
+		if report.Checks[0].Error != "connection refused" {
+			t.Errorf("Check() error = %q, want connection refused", report.Checks[0].Error)
+		}
+	})
+
+	t.Run("check times out", func(t *testing.T) {
+		c := New(10 * time.Millisecond)
+		c.Register("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		report := c.Check(context.Background())
+
+		if report.Ready {
+			t.Errorf("Check() = %+v, want not ready", report)
+		}
+	})
+
+	t.Run("draining is not ready", func(t *testing.T) {
+		c := New(time.Second)
+		c.Register("db", func(ctx context.Context) error { return nil })
+		c.Drain()
+
+		report := c.Check(context.Background())
+
+		if report.Ready || !report.Draining || report.Status != StatusUp {
+			t.Errorf("Check() = %+v, want up but not ready", report)
+		}
+	})
+}
+
+func TestRegisterReplaces(t *testing.T) {
+	c := New(time.Second)
+	c.Register("db", func(ctx context.Context) error { return errors.New("down") })
+	c.Register("db", func(ctx context.Context) error { return nil })
+
+	report := c.Check(context.Background())
+
+	if len(report.Checks) != 1 || !report.Ready {
+		t.Errorf("Check() = %+v, want a single healthy check", report)
+	}
+}
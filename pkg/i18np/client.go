@@ -87,3 +87,25 @@ func (i *I18n) TranslateWithParams(key string, params interface{}, languages ...
 		TemplateData: params,
 	}, languages...)
 }
+
+// Has reports whether key has a message in lang itself, without falling back to another language
+// example: i18n.Has("hello", "tr")
+func (i *I18n) Has(key string, lang string) bool {
+	want, err := language.Parse(lang)
+	if err != nil {
+		return false
+	}
+	_, tag, err := i18n.NewLocalizer(i.b, lang).LocalizeWithTag(&i18n.LocalizeConfig{MessageID: key})
+	return err == nil && tag == want
+}
+
+// Languages returns the languages that have messages loaded
+// example: i18n.Languages() // ["en", "tr"]
+func (i *I18n) Languages() []string {
+	tags := i.b.LanguageTags()
+	langs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		langs = append(langs, tag.String())
+	}
+	return langs
+}
@@ -0,0 +1,186 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/salihguru/idiogo/pkg/cancel"
+)
+
+// DefaultStepTimeout bounds a single stop step when none is configured
+const DefaultStepTimeout = 5 * time.Second
+
+// StopFunc releases a resource during shutdown
+type StopFunc func(ctx context.Context) error
+
+type hook struct {
+	name    string
+	stop    StopFunc
+	timeout time.Duration
+}
+
+// Config configures the shutdown sequence
+type Config struct {
+	// Drain is how long to wait after marking the application not ready
+	// It gives load balancers time to stop routing new requests
+	Drain time.Duration
+
+	// StepTimeout bounds every stop step that has no own timeout
+	StepTimeout time.Duration
+}
+
+// Manager runs the shutdown sequence:
+//  1. call every drain func, e.g. to fail readiness checks
+//  2. wait for the drain period
+//  3. cancel background jobs started with Go and wait for them
+//  4. stop every hook in reverse registration order, each with its own timeout
+type Manager struct {
+	cnf Config
+
+	mu     sync.Mutex
+	hooks  []hook
+	drains []func()
+
+	jobs       sync.WaitGroup
+	jobsCtx    context.Context
+	cancelJobs context.CancelFunc
+	once       sync.Once
+	report     Report
+}
+
+// New creates a lifecycle manager
+func New(cnf Config) *Manager {
+	if cnf.StepTimeout <= 0 {
+		cnf.StepTimeout = DefaultStepTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{cnf: cnf, jobsCtx: ctx, cancelJobs: cancel}
+}
+
+// OnDrain registers fn to be called as soon as shutdown starts
+func (m *Manager) OnDrain(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drains = append(m.drains, fn)
+}
+
+// Append registers a stop hook, hooks should be appended in start order
+// They are stopped in reverse order so dependencies outlive their users
+// An optional timeout overrides Config.StepTimeout for this hook
+func (m *Manager) Append(name string, stop StopFunc, timeout ...time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h := hook{name: name, stop: stop, timeout: m.cnf.StepTimeout}
+	if len(timeout) > 0 && timeout[0] > 0 {
+		h.timeout = timeout[0]
+	}
+	m.hooks = append(m.hooks, h)
+}
+
+// Go runs a background job until shutdown
+// Its context is canceled after the drain period and shutdown waits for it to return
+func (m *Manager) Go(fn func(ctx context.Context)) {
+	m.jobs.Add(1)
+	go func() {
+		defer m.jobs.Done()
+		fn(m.jobsCtx)
+	}()
+}
+
+// Step is the outcome of a single shutdown step
+type Step struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// Report describes a completed shutdown
+type Report struct {
+	Steps    []Step
+	Duration time.Duration
+}
+
+// Err joins the errors of every failed step
+func (r Report) Err() error {
+	var errs []error
+	for _, s := range r.Steps {
+		if s.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name, s.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "shutdown completed in %s", r.Duration.Round(time.Millisecond))
+	for _, s := range r.Steps {
+		status := "ok"
+		if s.Err != nil {
+			status = s.Err.Error()
+		}
+		fmt.Fprintf(&b, "\n  - %s: %s (%s)", s.Name, status, s.Duration.Round(time.Millisecond))
+	}
+	return b.String()
+}
+
+// Shutdown runs the shutdown sequence once, later calls return the first report
+// Every step runs even if an earlier one fails
+// The drain period is cut short when ctx is done
+func (m *Manager) Shutdown(ctx context.Context) Report {
+	m.once.Do(func() {
+		m.report = m.shutdown(ctx)
+	})
+	return m.report
+}
+
+func (m *Manager) shutdown(ctx context.Context) Report {
+	start := time.Now()
+	m.mu.Lock()
+	drains := append([]func(){}, m.drains...)
+	hooks := append([]hook{}, m.hooks...)
+	m.mu.Unlock()
+
+	var report Report
+	for _, fn := range drains {
+		fn()
+	}
+	if m.cnf.Drain > 0 {
+		report.Steps = append(report.Steps, m.step("drain", func() error {
+			timer := time.NewTimer(m.cnf.Drain)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+			}
+			return nil
+		}))
+	}
+
+	m.cancelJobs()
+	report.Steps = append(report.Steps, m.step("jobs", func() error {
+		return cancel.NewWithTimeout(ctx, m.cnf.StepTimeout, func(context.Context) error {
+			m.jobs.Wait()
+			return nil
+		})
+	}))
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		report.Steps = append(report.Steps, m.step(h.name, func() error {
+			return cancel.NewWithTimeout(ctx, h.timeout, cancel.TimeoutedFunc(h.stop))
+		}))
+	}
+	report.Duration = time.Since(start)
+	return report
+}
+
+func (m *Manager) step(name string, fn func() error) Step {
+	start := time.Now()
+	err := fn()
+	return Step{Name: name, Duration: time.Since(start), Err: err}
+}
@@ -0,0 +1,99 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShutdownOrder(t *testing.T) {
+	m := New(Config{StepTimeout: time.Second})
+	var order []string
+	m.OnDrain(func() { order = append(order, "drain") })
+	m.Append("db", func(ctx context.Context) error {
+		order = append(order, "db")
+		return nil
+	})
+	m.Append("rest", func(ctx context.Context) error {
+		order = append(order, "rest")
+		return nil
+	})
+
+	report := m.Shutdown(context.Background())
+
+	if err := report.Err(); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	want := []string{"drain", "rest", "db"}
+	if len(order) != len(want) {
+		t.Fatalf("Shutdown() order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("Shutdown() order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestShutdownContinuesOnError(t *testing.T) {
+	m := New(Config{StepTimeout: 50 * time.Millisecond})
+	closed := false
+	m.Append("db", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+	m.Append("slow", func(ctx context.Context) error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+	m.Append("broken", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+
+	report := m.Shutdown(context.Background())
+
+	if !closed {
+		t.Error("Shutdown() did not run the remaining hooks")
+	}
+	if report.Err() == nil {
+		t.Error("Shutdown() error = nil, want the failed steps")
+	}
+	for _, s := range report.Steps {
+		if s.Name == "slow" && !errors.Is(s.Err, context.DeadlineExceeded) {
+			t.Errorf("slow step error = %v, want deadline exceeded", s.Err)
+		}
+	}
+}
+
+func TestShutdownWaitsForJobs(t *testing.T) {
+	m := New(Config{Drain: 10 * time.Millisecond, StepTimeout: time.Second})
+	done := false
+	m.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		done = true
+	})
+
+	m.Shutdown(context.Background())
+
+	if !done {
+		t.Error("Shutdown() returned before the background job finished")
+	}
+}
+
+func TestShutdownOnce(t *testing.T) {
+	m := New(Config{})
+	calls := 0
+	m.Append("db", func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	m.Shutdown(context.Background())
+	m.Shutdown(context.Background())
+
+	if calls != 1 {
+		t.Errorf("hook called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,128 @@
+package list
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrInvalidCursor is returned for cursors that are malformed or not signed with the current key
+var ErrInvalidCursor = errors.New("list: invalid cursor")
+
+var cursorKey atomic.Pointer[[]byte]
+
+func init() {
+	SetCursorKey(nil)
+}
+
+// SetCursorKey sets the key signing the cursors, cursors signed with a previous key become invalid
+// An empty key is replaced with a random one, so cursors only work within the process
+// It is safe to call while requests are served
+func SetCursorKey(key []byte) {
+	if len(key) == 0 {
+		key = make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			panic(err)
+		}
+	}
+	key = append([]byte(nil), key...)
+	cursorKey.Store(&key)
+}
+
+// CursorRequest is a struct for cursor pagination request
+type CursorRequest struct {
+
+	// Cursor is the next_cursor or prev_cursor of a previous page
+	// The first page is returned when Cursor is empty
+	Cursor string `query:"cursor" validate:"omitempty,max=2048"`
+
+	// Limit is a query parameter for limit number of items per page
+	// Limit is validated to be greater than 0
+	Limit *int `query:"limit" validate:"omitempty,gt=0"`
+}
+
+// Default sets Limit like PagiRequest.Default
+func (r *CursorRequest) Default() {
+	pagi := PagiRequest{Limit: r.Limit}
+	pagi.Default()
+	r.Limit = pagi.Limit
+}
+
+func (r *CursorRequest) LimitValue() int {
+	return *r.Limit
+}
+
+// Cursor is the position a cursor page starts from
+type Cursor struct {
+
+	// Order identifies the order the values were read in, a cursor is only valid for its order
+	Order string `json:"o"`
+
+	// Values are the sort key values of the row the page starts after, one per key
+	Values []json.RawMessage `json:"v"`
+
+	// Backward pages go towards the start, before the row
+	Backward bool `json:"b,omitempty"`
+}
+
+// EncodeCursor returns the opaque form of c, signed with the cursor key
+func EncodeCursor(c Cursor) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload)), nil
+}
+
+// DecodeCursor verifies and decodes a cursor of EncodeCursor
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	encoded, sig, ok := strings.Cut(s, ".")
+	if !ok {
+		return c, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, sign(payload)) {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, *cursorKey.Load())
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// CursorPage is a page of items with the cursors of its neighbours
+type CursorPage[T any] struct {
+	Items []T `json:"items"`
+	Limit int `json:"limit"`
+
+	// NextCursor continues after the last item, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	// PrevCursor continues before the first item, empty on the first page
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// CursorPager is implemented by every CursorPage, it lets transports read the cursors of a page without its item type
+type CursorPager interface {
+	CursorInfo() (limit int, next, prev string)
+}
+
+func (p *CursorPage[T]) CursorInfo() (limit int, next, prev string) {
+	return p.Limit, p.NextCursor, p.PrevCursor
+}
@@ -0,0 +1,34 @@
+package list
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	t.Cleanup(func() { SetCursorKey(nil) })
+	SetCursorKey([]byte("secret"))
+
+	c := Cursor{Order: "created_at DESC,id DESC", Values: []json.RawMessage{json.RawMessage(`"2025-12-18T10:00:00Z"`), json.RawMessage(`"id"`)}, Backward: true}
+	s, err := EncodeCursor(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecodeCursor(s)
+	if err != nil || !reflect.DeepEqual(got, c) {
+		t.Fatalf("DecodeCursor() = %+v, %v, want %+v", got, err, c)
+	}
+
+	for _, bad := range []string{"", "abc", s[:len(s)-2], "x" + s} {
+		if _, err := DecodeCursor(bad); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+
+	SetCursorKey([]byte("rotated"))
+	if _, err := DecodeCursor(s); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("DecodeCursor() with another key = %v, want ErrInvalidCursor", err)
+	}
+}
@@ -0,0 +1,38 @@
+package list
+
+// Page is a page of items with the totals of the whole result
+type Page[T any] struct {
+	Items      []T   `json:"items"`
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+}
+
+// Pager is implemented by every Page, it lets transports read the position of a page without its item type
+type Pager interface {
+	PageInfo() (page, limit, totalPages int)
+}
+
+// NewPage builds the page of pagi holding items out of total matching items
+func NewPage[T any](items []T, pagi PagiRequest, total int64) *Page[T] {
+	pagi.Default()
+	if items == nil {
+		items = []T{}
+	}
+	page, limit := *pagi.Page, *pagi.Limit
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	return &Page[T]{
+		Items:      items,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+	}
+}
+
+func (p *Page[T]) PageInfo() (page, limit, totalPages int) {
+	return p.Page, p.Limit, p.TotalPages
+}
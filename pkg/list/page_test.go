@@ -0,0 +1,55 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/salihguru/idiogo/pkg/ptr"
+)
+
+func TestNewPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagi       PagiRequest
+		total      int64
+		totalPages int
+		hasNext    bool
+	}{
+		{name: "empty", pagi: PagiRequest{}, total: 0, totalPages: 0, hasNext: false},
+		{name: "first of many", pagi: PagiRequest{Page: ptr.Int(1), Limit: ptr.Int(10)}, total: 23, totalPages: 3, hasNext: true},
+		{name: "last", pagi: PagiRequest{Page: ptr.Int(3), Limit: ptr.Int(10)}, total: 23, totalPages: 3, hasNext: false},
+		{name: "exact fit", pagi: PagiRequest{Page: ptr.Int(2), Limit: ptr.Int(5)}, total: 10, totalPages: 2, hasNext: false},
+		{name: "past the end", pagi: PagiRequest{Page: ptr.Int(9), Limit: ptr.Int(5)}, total: 10, totalPages: 2, hasNext: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPage[int](nil, tt.pagi, tt.total)
+			if p.Items == nil {
+				t.Error("Items is nil, want an empty slice")
+			}
+			if p.TotalPages != tt.totalPages || p.HasNext != tt.hasNext {
+				t.Errorf("TotalPages, HasNext = %d, %v, want %d, %v", p.TotalPages, p.HasNext, tt.totalPages, tt.hasNext)
+			}
+		})
+	}
+}
+
+func TestDefaultMaxLimit(t *testing.T) {
+	t.Cleanup(func() { SetMaxLimit(0) })
+	SetMaxLimit(50)
+
+	limit := 500
+	r := PagiRequest{Limit: &limit}
+	r.Default()
+	if *r.Limit != 50 {
+		t.Errorf("Limit = %d, want the max limit 50", *r.Limit)
+	}
+	if limit != 500 {
+		t.Errorf("caller limit = %d, want it unchanged", limit)
+	}
+
+	r = PagiRequest{}
+	r.Default()
+	if *r.Page != 1 || *r.Limit != DefaultLimit {
+		t.Errorf("Page, Limit = %d, %d, want 1, %d", *r.Page, *r.Limit, DefaultLimit)
+	}
+}
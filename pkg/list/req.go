@@ -1,6 +1,36 @@
 package list
 
-import "gorm.io/gorm"
+import (
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// DefaultLimit is the limit of requests without one
+const DefaultLimit = 10
+
+// DefaultMaxLimit is the largest limit until SetMaxLimit is called
+const DefaultMaxLimit = 100
+
+var maxLimit atomic.Int64
+
+func init() {
+	maxLimit.Store(DefaultMaxLimit)
+}
+
+// SetMaxLimit sets the largest limit Default lets through, larger limits are lowered to it
+// It is safe to call while requests are served, n <= 0 restores DefaultMaxLimit
+func SetMaxLimit(n int) {
+	if n <= 0 {
+		n = DefaultMaxLimit
+	}
+	maxLimit.Store(int64(n))
+}
+
+// MaxLimit returns the largest limit Default lets through
+func MaxLimit() int {
+	return int(maxLimit.Load())
+}
 
 // PagiRequest is a struct for pagination request
 type PagiRequest struct {
@@ -19,6 +49,7 @@ type PagiRequest struct {
 // Default is a method to set default value for PagiRequest
 // Default set Page to 1 if Page is nil or less than 1
 // Default set Limit to 10 if Limit is nil or less than 1
+// Default lowers Limit to MaxLimit if it is greater
 func (r *PagiRequest) Default() {
 	if r.Page == nil || *r.Page <= 0 {
 		r.Page = new(int)
@@ -26,7 +57,11 @@ func (r *PagiRequest) Default() {
 	}
 	if r.Limit == nil || *r.Limit <= 0 {
 		r.Limit = new(int)
-		*r.Limit = 10
+		*r.Limit = DefaultLimit
+	}
+	if limit := MaxLimit(); *r.Limit > limit {
+		r.Limit = new(int)
+		*r.Limit = limit
 	}
 }
 
@@ -0,0 +1,375 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metric types of the Prometheus text format
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// DefBuckets are latency buckets in seconds, from 5ms to 10s
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var nameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// labelSep joins label values into series keys, it can't appear in valid UTF-8
+const labelSep = "\xff"
+
+// Registry holds the metrics exposed together on one endpoint
+// Registering the same metric twice returns the existing one, conflicting definitions panic
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]family
+	hooks    []func()
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+type family interface {
+	desc() *desc
+	write(b *strings.Builder)
+}
+
+type desc struct {
+	name   string
+	help   string
+	typ    string
+	labels []string
+}
+
+func (d *desc) header(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.typ)
+}
+
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, labelSep)
+}
+
+// series formats the labels of key plus the extra name value pairs
+func (d *desc) series(key string, extra ...string) string {
+	var values []string
+	if len(d.labels) > 0 {
+		values = strings.Split(key, labelSep)
+	}
+	pairs := make([]string, 0, len(d.labels)+len(extra)/2)
+	for i, l := range d.labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, l, escapeLabel(values[i])))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[i], escapeLabel(extra[i+1])))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (r *Registry) register(d *desc, create func() family) family {
+	if !nameRegexp.MatchString(d.name) {
+		panic(fmt.Sprintf("metrics: invalid metric name %q", d.name))
+	}
+	for _, l := range d.labels {
+		if !nameRegexp.MatchString(l) || strings.HasPrefix(l, "__") {
+			panic(fmt.Sprintf("metrics: invalid label name %q of %s", l, d.name))
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[d.name]; ok {
+		existing := f.desc()
+		if existing.typ != d.typ || !slices.Equal(existing.labels, d.labels) {
+			panic(fmt.Sprintf("metrics: %s is already registered as a %s with labels %v", d.name, existing.typ, existing.labels))
+		}
+		return f
+	}
+	f := create()
+	r.families[d.name] = f
+	return f
+}
+
+// OnCollect registers fn to run before every collection, e.g. to refresh gauges from a snapshot
+func (r *Registry) OnCollect(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, fn)
+}
+
+// Counter registers a counter partitioned by the given label names
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	d := &desc{name: name, help: help, typ: TypeCounter, labels: labels}
+	f := r.register(d, func() family { return &Counter{values: newValues(d)} })
+	c, ok := f.(*Counter)
+	if !ok {
+		panic(fmt.Sprintf("metrics: %s is not a counter", name))
+	}
+	return c
+}
+
+// Gauge registers a gauge partitioned by the given label names
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	d := &desc{name: name, help: help, typ: TypeGauge, labels: labels}
+	f := r.register(d, func() family { return &Gauge{values: newValues(d)} })
+	g, ok := f.(*Gauge)
+	if !ok {
+		panic(fmt.Sprintf("metrics: %s is not a gauge", name))
+	}
+	return g
+}
+
+// CounterFunc registers a counter read from fn on every collection
+func (r *Registry) CounterFunc(name, help string, fn func() float64) {
+	d := &desc{name: name, help: help, typ: TypeCounter}
+	r.register(d, func() family { return &funcMetric{d: d, fn: fn} })
+}
+
+// GaugeFunc registers a gauge read from fn on every collection
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	d := &desc{name: name, help: help, typ: TypeGauge}
+	r.register(d, func() family { return &funcMetric{d: d, fn: fn} })
+}
+
+// Histogram registers a histogram with the given upper bounds, DefBuckets when empty
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	buckets = slices.Clone(buckets)
+	sort.Float64s(buckets)
+	d := &desc{name: name, help: help, typ: TypeHistogram, labels: labels}
+	f := r.register(d, func() family {
+		return &Histogram{d: d, buckets: buckets, series: make(map[string]*histogramSeries)}
+	})
+	h, ok := f.(*Histogram)
+	if !ok {
+		panic(fmt.Sprintf("metrics: %s is not a histogram", name))
+	}
+	return h
+}
+
+// Write runs the collect hooks and returns every metric in the Prometheus text format
+func (r *Registry) Write() string {
+	r.mu.Lock()
+	hooks := slices.Clone(r.hooks)
+	families := make([]family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].desc().name < families[j].desc().name
+	})
+	var b strings.Builder
+	for _, f := range families {
+		f.write(&b)
+	}
+	return b.String()
+}
+
+// values is a set of float series keyed by label values
+type values struct {
+	d  *desc
+	mu sync.Mutex
+	m  map[string]float64
+}
+
+func newValues(d *desc) values {
+	return values{d: d, m: make(map[string]float64)}
+}
+
+func (v *values) desc() *desc {
+	return v.d
+}
+
+func (v *values) add(delta float64, labels []string) {
+	key := v.d.key(labels)
+	v.mu.Lock()
+	v.m[key] += delta
+	v.mu.Unlock()
+}
+
+func (v *values) set(val float64, labels []string) {
+	key := v.d.key(labels)
+	v.mu.Lock()
+	v.m[key] = val
+	v.mu.Unlock()
+}
+
+func (v *values) get(labels []string) float64 {
+	key := v.d.key(labels)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.m[key]
+}
+
+func (v *values) write(b *strings.Builder) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.d.header(b)
+	keys := make([]string, 0, len(v.m))
+	for k := range v.m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s%s %s\n", v.d.name, v.d.series(k), formatFloat(v.m[k]))
+	}
+}
+
+// Counter is a value that only goes up
+type Counter struct {
+	values
+}
+
+// Inc adds one to the series of the label values
+func (c *Counter) Inc(labels ...string) {
+	c.add(1, labels)
+}
+
+// Add adds delta to the series of the label values, negative deltas are ignored
+func (c *Counter) Add(delta float64, labels ...string) {
+	if delta < 0 {
+		return
+	}
+	c.add(delta, labels)
+}
+
+// Value returns the current value of the series of the label values
+func (c *Counter) Value(labels ...string) float64 {
+	return c.get(labels)
+}
+
+// Gauge is a value that can go up and down
+type Gauge struct {
+	values
+}
+
+// Set sets the series of the label values
+func (g *Gauge) Set(val float64, labels ...string) {
+	g.set(val, labels)
+}
+
+// Add adds delta to the series of the label values
+func (g *Gauge) Add(delta float64, labels ...string) {
+	g.add(delta, labels)
+}
+
+// Value returns the current value of the series of the label values
+func (g *Gauge) Value(labels ...string) float64 {
+	return g.get(labels)
+}
+
+type funcMetric struct {
+	d  *desc
+	fn func() float64
+}
+
+func (f *funcMetric) desc() *desc {
+	return f.d
+}
+
+func (f *funcMetric) write(b *strings.Builder) {
+	f.d.header(b)
+	fmt.Fprintf(b, "%s %s\n", f.d.name, formatFloat(f.fn()))
+}
+
+// Histogram counts observations in cumulative buckets
+type Histogram struct {
+	d       *desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *Histogram) desc() *desc {
+	return h.d
+}
+
+// Observe records v in the series of the label values
+func (h *Histogram) Observe(v float64, labels ...string) {
+	key := h.d.key(labels)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += v
+}
+
+func (h *Histogram) write(b *strings.Builder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.d.header(b)
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := h.series[k]
+		var cumulative uint64
+		for i, le := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(b, "%s_bucket%s %d\n", h.d.name, h.d.series(k, "le", formatFloat(le)), cumulative)
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", h.d.name, h.d.series(k, "le", "+Inf"), s.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", h.d.name, h.d.series(k), formatFloat(s.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", h.d.name, h.d.series(k), s.count)
+	}
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return fmt.Sprintf("%g", f)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
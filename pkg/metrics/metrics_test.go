@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+	c := r.Counter("todos_created_total", "Todos created.", "status")
+	c.Inc("pending")
+	c.Add(2, "pending")
+	c.Add(-1, "pending")
+	r.Gauge("queue_size", "Queued jobs.").Set(3)
+	r.GaugeFunc("answer", "The answer.", func() float64 { return 42 })
+	h := r.Histogram("latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	h.Observe(0.05, "/todos")
+	h.Observe(0.5, "/todos")
+	h.Observe(2, "/todos")
+
+	want := `# HELP answer The answer.
+# TYPE answer gauge
+answer 42
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/todos",le="0.1"} 1
+latency_seconds_bucket{route="/todos",le="1"} 2
+latency_seconds_bucket{route="/todos",le="+Inf"} 3
+latency_seconds_sum{route="/todos"} 2.55
+latency_seconds_count{route="/todos"} 3
+# HELP queue_size Queued jobs.
+# TYPE queue_size gauge
+queue_size 3
+# HELP todos_created_total Todos created.
+# TYPE todos_created_total counter
+todos_created_total{status="pending"} 3
+`
+	if got := r.Write(); got != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRegistryReturnsExisting(t *testing.T) {
+	r := NewRegistry()
+	a := r.Counter("hits_total", "Hits.", "path")
+	b := r.Counter("hits_total", "Hits.", "path")
+	if a != b {
+		t.Error("Counter() registered the same metric twice")
+	}
+}
+
+func TestRegistryConflictPanics(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("hits_total", "Hits.")
+	defer func() {
+		if recover() == nil {
+			t.Error("Gauge() did not panic on a conflicting definition")
+		}
+	}()
+	r.Gauge("hits_total", "Hits.")
+}
+
+func TestLabelEscaping(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("errors_total", "Errors.", "msg").Inc("say \"hi\"\n")
+	if got := r.Write(); !strings.Contains(got, `errors_total{msg="say \"hi\"\n"} 1`) {
+		t.Errorf("Write() = %s, want escaped label", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	RegisterRuntime(r)
+	rec := httptest.NewRecorder()
+	Handler(r).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ContentType)
+	}
+	if !strings.Contains(rec.Body.String(), "go_goroutines ") {
+		t.Errorf("body has no runtime metrics:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"runtime"
+	"sync"
+)
+
+// RegisterRuntime adds Go runtime metrics to r
+// The memory stats are read once per collection
+func RegisterRuntime(r *Registry) {
+	var (
+		mu sync.Mutex
+		ms runtime.MemStats
+	)
+	r.OnCollect(func() {
+		mu.Lock()
+		runtime.ReadMemStats(&ms)
+		mu.Unlock()
+	})
+	stat := func(fn func(*runtime.MemStats) float64) func() float64 {
+		return func() float64 {
+			mu.Lock()
+			defer mu.Unlock()
+			return fn(&ms)
+		}
+	}
+	r.Gauge("go_info", "Information about the Go environment.", "version").Set(1, runtime.Version())
+	r.GaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	r.GaugeFunc("go_memstats_heap_alloc_bytes", "Number of heap bytes allocated and still in use.", stat(func(ms *runtime.MemStats) float64 {
+		return float64(ms.HeapAlloc)
+	}))
+	r.GaugeFunc("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", stat(func(ms *runtime.MemStats) float64 {
+		return float64(ms.HeapInuse)
+	}))
+	r.GaugeFunc("go_memstats_heap_objects", "Number of allocated objects.", stat(func(ms *runtime.MemStats) float64 {
+		return float64(ms.HeapObjects)
+	}))
+	r.GaugeFunc("go_memstats_sys_bytes", "Number of bytes obtained from system.", stat(func(ms *runtime.MemStats) float64 {
+		return float64(ms.Sys)
+	}))
+	r.CounterFunc("go_memstats_alloc_bytes_total", "Total number of bytes allocated, even if freed.", stat(func(ms *runtime.MemStats) float64 {
+		return float64(ms.TotalAlloc)
+	}))
+	r.CounterFunc("go_gc_cycles_total", "Number of completed GC cycles.", stat(func(ms *runtime.MemStats) float64 {
+		return float64(ms.NumGC)
+	}))
+	r.CounterFunc("go_gc_pause_seconds_total", "Total GC stop-the-world pause time in seconds.", stat(func(ms *runtime.MemStats) float64 {
+		return float64(ms.PauseTotalNs) / 1e9
+	}))
+}
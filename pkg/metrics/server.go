@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ContentType is the media type of the Prometheus text format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler serves every metric of r in the Prometheus text format
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_, _ = w.Write([]byte(r.Write()))
+	})
+}
+
+// Server is the admin listener exposing the metrics apart from the public API
+// It implements server.Listener
+type Server struct {
+	srv *http.Server
+}
+
+// NewServer creates an admin listener serving r on path at host:port
+func NewServer(host, port, path string, r *Registry) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(path, Handler(r))
+	return &Server{srv: &http.Server{
+		Addr:              net.JoinHostPort(host, port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}}
+}
+
+func (s *Server) Listen() error {
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type testBase struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testItem struct {
+	testBase
+	Title  string    `json:"title" validate:"required,min=3,max=255"`
+	Status string    `json:"status" validate:"omitempty,oneof=open done"`
+	Tags   []string  `json:"tags" validate:"max=5,dive,min=2"`
+	Score  int       `json:"score" validate:"gte=0,lt=10"`
+	Next   *testItem `json:"next"`
+	secret string
+}
+
+type testUpdate struct {
+	ID    uuid.UUID `params:"id" validate:"required,uuid"`
+	Title *string   `json:"title" validate:"omitempty,min=3"`
+	Page  *int      `query:"page" validate:"omitempty,gt=0"`
+}
+
+func TestSchemaComponents(t *testing.T) {
+	d := New(Info{Title: "test", Version: "1"})
+	s := d.Schema(reflect.TypeOf([]*testItem{}))
+	if s.Type != "array" || s.Items.Ref != "#/components/schemas/openapi.testItem" {
+		t.Fatalf("Schema([]*testItem) = %+v, want an array of references", s)
+	}
+	item := d.Components.Schemas["openapi.testItem"]
+	if item == nil {
+		t.Fatal("testItem was not added to the components")
+	}
+	if _, ok := item.Properties["secret"]; ok {
+		t.Error("unexported field is in the schema")
+	}
+	if id := item.Properties["id"]; id == nil || id.Format != "uuid" {
+		t.Errorf("embedded id = %+v, want a flattened uuid", id)
+	}
+	if c := item.Properties["created_at"]; c == nil || c.Format != "date-time" {
+		t.Errorf("created_at = %+v, want a date-time", c)
+	}
+	if next := item.Properties["next"]; next.Ref != "#/components/schemas/openapi.testItem" {
+		t.Errorf("next = %+v, want a reference to itself", next)
+	}
+	if !reflect.DeepEqual(item.Required, []string{"title"}) {
+		t.Errorf("required = %v, want [title]", item.Required)
+	}
+}
+
+type testPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+func TestSchemaGenericName(t *testing.T) {
+	d := New(Info{})
+	s := d.Schema(reflect.TypeOf(&testPage[*testBase]{}))
+	if s.Ref != "#/components/schemas/openapi.testPage_openapi.testBase" {
+		t.Errorf("Schema(*testPage[*testBase]) = %+v, want a reference without import paths", s)
+	}
+}
+
+func TestSchemaRules(t *testing.T) {
+	d := New(Info{})
+	d.Schema(reflect.TypeOf(testItem{}))
+	item := d.Components.Schemas["openapi.testItem"]
+
+	title := item.Properties["title"]
+	if *title.MinLength != 3 || *title.MaxLength != 255 {
+		t.Errorf("title = %+v, want lengths 3 to 255", title)
+	}
+	if status := item.Properties["status"]; !reflect.DeepEqual(status.Enum, []any{"open", "done"}) {
+		t.Errorf("status enum = %v, want [open done]", status.Enum)
+	}
+	tags := item.Properties["tags"]
+	if *tags.MaxItems != 5 || *tags.Items.MinLength != 2 {
+		t.Errorf("tags = %+v, items %+v, want at most 5 items of at least 2 characters", tags, tags.Items)
+	}
+	score := item.Properties["score"]
+	if *score.Minimum != 0 || *score.ExclusiveMaximum != 10 {
+		t.Errorf("score = %+v, want 0 <= score < 10", score)
+	}
+}
+
+func TestBodySchemaSkipsOtherSources(t *testing.T) {
+	d := New(Info{})
+	s := d.BodySchema(reflect.TypeOf(testUpdate{}), "params", "query")
+	if s.Ref != "" {
+		t.Fatalf("BodySchema = %+v, want an inline schema", s)
+	}
+	if len(s.Properties) != 1 || s.Properties["title"] == nil {
+		t.Errorf("properties = %v, want only title", s.Properties)
+	}
+}
+
+func TestParameters(t *testing.T) {
+	d := New(Info{})
+	path := d.Parameters(reflect.TypeOf(testUpdate{}), InPath, "params")
+	if len(path) != 1 || path[0].Name != "id" || !path[0].Required || path[0].Schema.Format != "uuid" {
+		t.Fatalf("path parameters = %+v, want a required uuid id", path)
+	}
+	query := d.Parameters(reflect.TypeOf(testUpdate{}), InQuery, "query")
+	if len(query) != 1 || query[0].Name != "page" || query[0].Required || *query[0].Schema.ExclusiveMinimum != 0 {
+		t.Fatalf("query parameters = %+v, want an optional positive page", query)
+	}
+}
+
+func TestAddOperation(t *testing.T) {
+	d := New(Info{})
+	d.AddOperation("GET", "/items", &Operation{OperationID: "list"})
+	d.AddOperation("POST", "/items", &Operation{OperationID: "create"})
+	item := *d.Paths["/items"]
+	if item["get"].OperationID != "list" || item["post"].OperationID != "create" {
+		t.Errorf("path item = %v, want get and post operations", item)
+	}
+}
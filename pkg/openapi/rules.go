@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// isRequired reports whether the validate rules of a field require it
+func isRequired(rules string) bool {
+	for _, r := range strings.Split(rules, ",") {
+		if r == "dive" {
+			return false
+		}
+		if r == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRules turns the validate rules of a field of type t into schema constraints
+// Rules after dive apply to the items, rules without a schema counterpart are ignored
+func applyRules(s *Schema, t reflect.Type, rules string) {
+	if s == nil || s.Ref != "" || rules == "" {
+		return
+	}
+	t = deref(t)
+	list := strings.Split(rules, ",")
+	for i, r := range list {
+		if r == "dive" {
+			if s.Items != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				applyRules(s.Items, t.Elem(), strings.Join(list[i+1:], ","))
+			}
+			return
+		}
+		name, param, _ := strings.Cut(r, "=")
+		applyRule(s, name, param)
+	}
+}
+
+func applyRule(s *Schema, name, param string) {
+	switch name {
+	case "min", "gte":
+		setBound(s, param, true, false, false)
+	case "max", "lte":
+		setBound(s, param, false, true, false)
+	case "gt":
+		setBound(s, param, true, false, true)
+	case "lt":
+		setBound(s, param, false, true, true)
+	case "len":
+		setBound(s, param, true, true, false)
+	case "oneof":
+		for _, v := range strings.Fields(param) {
+			s.Enum = append(s.Enum, enumValue(s.Type, v))
+		}
+	case "email":
+		s.Format = "email"
+	case "url", "uri", "http_url":
+		s.Format = "uri"
+	case "uuid", "uuid4":
+		s.Format = "uuid"
+	case "ip", "ipv4":
+		s.Format = "ipv4"
+	case "ipv6":
+		s.Format = "ipv6"
+	case "hostname":
+		s.Format = "hostname"
+	case "datetime":
+		s.Format = "date-time"
+	}
+}
+
+// setBound applies a numeric rule as a length for strings and arrays and as a value for anything else
+// Exclusive length bounds become inclusive ones, e.g. gt=2 on a string is minLength 3
+func setBound(s *Schema, param string, lower, upper, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	if s.Type != "string" && s.Type != "array" {
+		switch {
+		case lower && upper:
+			s.Minimum, s.Maximum = &n, &n
+		case lower && exclusive:
+			s.ExclusiveMinimum = &n
+		case lower:
+			s.Minimum = &n
+		case exclusive:
+			s.ExclusiveMaximum = &n
+		default:
+			s.Maximum = &n
+		}
+		return
+	}
+	minLen, maxLen := int(n), int(n)
+	if exclusive {
+		minLen, maxLen = minLen+1, maxLen-1
+	}
+	if s.Type == "string" {
+		if lower {
+			s.MinLength = &minLen
+		}
+		if upper {
+			s.MaxLength = &maxLen
+		}
+		return
+	}
+	if lower {
+		s.MinItems = &minLen
+	}
+	if upper {
+		s.MaxItems = &maxLen
+	}
+}
+
+func enumValue(typ, v string) any {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
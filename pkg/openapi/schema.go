@@ -0,0 +1,220 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	uuidType          = reflect.TypeOf(uuid.UUID{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// componentName drops the characters component names can't hold, e.g. the brackets of generic types
+var componentName = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// typeArgPath matches the import paths of generic type arguments, e.g. github.com/x/ in Page[*github.com/x/todo.Todo]
+var typeArgPath = regexp.MustCompile(`(?:[a-zA-Z0-9._~-]+/)+`)
+
+// Schema returns the schema of t
+// Named structs are added to the components and referenced
+func (d *Document) Schema(t reflect.Type) *Schema {
+	return d.schema(t, nil)
+}
+
+// BodySchema returns the schema of a request body of type t
+// Fields without a json tag but with one of the skip tags are read from elsewhere, e.g. params:"id", and left out
+func (d *Document) BodySchema(t reflect.Type, skip ...string) *Schema {
+	t = deref(t)
+	if t.Kind() != reflect.Struct || t == timeType || !hasSkipped(t, skip) {
+		return d.Schema(t)
+	}
+	return d.object(t, func(f reflect.StructField) bool {
+		_, ok := f.Tag.Lookup("json")
+		return !ok && slices.ContainsFunc(skip, func(tag string) bool {
+			_, ok := f.Tag.Lookup(tag)
+			return ok
+		})
+	})
+}
+
+// Parameters lists the fields of struct t tagged with tag as parameters in the location in
+// Path parameters are always required, others when their validate tag requires them
+func (d *Document) Parameters(t reflect.Type, in, tag string) []*Parameter {
+	var params []*Parameter
+	t = deref(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, f := range fields(t) {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		s := d.Schema(f.Type)
+		rules := f.Tag.Get("validate")
+		applyRules(s, f.Type, rules)
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       in,
+			Required: in == InPath || isRequired(rules),
+			Schema:   s,
+		})
+	}
+	return params
+}
+
+func (d *Document) schema(t reflect.Type, skip func(reflect.StructField) bool) *Schema {
+	t = deref(t)
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// the encoded form is unknown
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: d.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.Schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.object(t, skip)
+		}
+		return d.ref(t)
+	}
+	return &Schema{}
+}
+
+// ref adds the named struct t to the components and references it
+func (d *Document) ref(t reflect.Type) *Schema {
+	if name, ok := d.names[t]; ok {
+		return SchemaRef(name)
+	}
+	name := d.uniqueName(t)
+	d.names[t] = name
+	// reserve the name before walking the fields so recursive types terminate
+	d.Components.Schemas[name] = &Schema{}
+	*d.Components.Schemas[name] = *d.object(t, nil)
+	return SchemaRef(name)
+}
+
+// uniqueName names t after its package and type, e.g. todo.Todo
+func (d *Document) uniqueName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	// generic types keep the package name of their arguments, e.g. list.Page_todo.Todo
+	base := componentName.ReplaceAllString(typeArgPath.ReplaceAllString(t.Name(), ""), "_")
+	base = strings.TrimSuffix(base, "_")
+	if pkg != "" {
+		base = pkg + "." + base
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := d.Components.Schemas[name]; !taken {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// object describes the JSON form of struct t
+func (d *Document) object(t reflect.Type, skip func(reflect.StructField) bool) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, f := range fields(t) {
+		if skip != nil && skip(f) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		rules := f.Tag.Get("validate")
+		prop := d.Schema(f.Type)
+		if prop.Ref == "" {
+			applyRules(prop, f.Type, rules)
+		}
+		s.Properties[name] = prop
+		if isRequired(rules) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// fields returns the exported fields of t with embedded structs without a json name flattened
+func fields(t reflect.Type) []reflect.StructField {
+	var list []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && name == "" && deref(f.Type).Kind() == reflect.Struct {
+			list = append(list, fields(deref(f.Type))...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		list = append(list, f)
+	}
+	return list
+}
+
+func hasSkipped(t reflect.Type, skip []string) bool {
+	for _, f := range fields(t) {
+		if _, ok := f.Tag.Lookup("json"); ok {
+			continue
+		}
+		for _, tag := range skip {
+			if _, ok := f.Tag.Lookup(tag); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,351 @@
+package query
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Filter operators, written as filter[field][op]=value in the query string
+// filter[field]=value is the same as filter[field][eq]=value
+const (
+	OpEq    = "eq"
+	OpNe    = "ne"
+	OpIn    = "in"
+	OpNin   = "nin"
+	OpGt    = "gt"
+	OpGte   = "gte"
+	OpLt    = "lt"
+	OpLte   = "lte"
+	OpILike = "ilike"
+)
+
+// Validation tags of the filter errors
+const (
+	TagFilterField = "filter_field"
+	TagFilterOp    = "filter_op"
+	TagFilterType  = "filter_type"
+	TagFilterValue = "filter_value"
+	TagFilterMax   = "filter_max"
+)
+
+// MaxFilterValues bounds the values of an in or nin filter
+const MaxFilterValues = 100
+
+// jsonbOps are the operators with a JSONB builder
+var jsonbOps = []string{OpEq, OpGte, OpLte, OpILike}
+
+// Filter holds the filters of a request, field -> operator -> value
+type Filter map[string]map[string]string
+
+// filterKey matches filter[field] and filter[field][op]
+var filterKey = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// ParseFilter collects the filter[field][op] parameters of a query string, ignoring the others
+// Repeated in and nin parameters are joined, e.g. filter[status][in]=a&filter[status][in]=b is a,b
+// filter[field][eq] wins over filter[field] when both are given, whatever their order
+func ParseFilter(values url.Values) Filter {
+	var f Filter
+	for key, vals := range values {
+		m := filterKey.FindStringSubmatch(key)
+		if m == nil || len(vals) == 0 {
+			continue
+		}
+		field, op := m[1], m[2]
+		if op == "" {
+			if _, ok := values["filter["+field+"]["+OpEq+"]"]; ok {
+				continue
+			}
+			op = OpEq
+		}
+		if f == nil {
+			f = make(Filter)
+		}
+		if f[field] == nil {
+			f[field] = make(map[string]string)
+		}
+		if op == OpIn || op == OpNin {
+			f[field][op] = strings.Join(vals, ",")
+			continue
+		}
+		f[field][op] = vals[len(vals)-1]
+	}
+	return f
+}
+
+// FilterReq is embedded by list requests accepting filters, the rest query binding fills it
+type FilterReq struct {
+	Filter Filter `query:"-" json:"-"`
+}
+
+func (r *FilterReq) SetFilter(f Filter) {
+	r.Filter = f
+}
+
+func (r FilterReq) QueryFilter() Filter {
+	return r.Filter
+}
+
+// Filterer is implemented by requests with filters, ParamChecker checks their filters against FilterSpec
+type Filterer interface {
+	QueryFilter() Filter
+	FilterSpec() *FilterSpec
+}
+
+// FilterField allows filtering a field with some operators, see FilterOn
+type FilterField struct {
+	name     string
+	column   string
+	jsonKey  string
+	ops      []string
+	values   []string
+	typeName string
+	parse    func(string) (any, error)
+}
+
+// FilterOn allows filtering name with ops, the values are coerced to T
+// T is a string, bool, integer, float, time.Time, uuid.UUID or a TextUnmarshaler, named types like todo.Status included
+// Times are RFC 3339 date-times or dates like 2026-01-01
+// example:
+//
+//	query.FilterOn[time.Time]("created_at", query.OpGte, query.OpLte)
+func FilterOn[T any](name string, ops ...string) FilterField {
+	typeName, parse := filterParser(reflect.TypeOf((*T)(nil)).Elem())
+	return FilterField{name: name, column: name, ops: ops, typeName: typeName, parse: parse}
+}
+
+// Column compares column instead of the column named like the field
+func (f FilterField) Column(column string) FilterField {
+	f.column = column
+	return f
+}
+
+// JSONKey compares the text of key inside the JSONB column, with the eq, gte, lte and ilike operators
+// gte and lte compare the key as a number, use them with numeric T
+func (f FilterField) JSONKey(key string) FilterField {
+	f.jsonKey = key
+	return f
+}
+
+// Values limits the values to the given ones
+func (f FilterField) Values(values ...string) FilterField {
+	f.values = values
+	return f
+}
+
+// FilterSpec is the allowlist of the filters of an entity
+type FilterSpec struct {
+	fields map[string]FilterField
+	names  string
+}
+
+// NewFilterSpec builds the allowlist of fields, it panics on invalid columns and operators a field can't use
+func NewFilterSpec(fields ...FilterField) *FilterSpec {
+	s := &FilterSpec{fields: make(map[string]FilterField, len(fields))}
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !ValidIdent(f.column) {
+			panic(fmt.Sprintf("query: filter %s has an invalid column %q", f.name, f.column))
+		}
+		for _, op := range f.ops {
+			if !slices.Contains(filterOps, op) || (f.jsonKey != "" && !slices.Contains(jsonbOps, op)) {
+				panic(fmt.Sprintf("query: filter %s can't use the %s operator", f.name, op))
+			}
+		}
+		s.fields[f.name] = f
+		names = append(names, f.name)
+	}
+	slices.Sort(names)
+	s.names = strings.Join(names, " ")
+	return s
+}
+
+// Allowed returns the operators of every filterable field
+func (s *FilterSpec) Allowed() map[string][]string {
+	allowed := make(map[string][]string, len(s.fields))
+	for name, f := range s.fields {
+		allowed[name] = slices.Clone(f.ops)
+	}
+	return allowed
+}
+
+var filterOps = []string{OpEq, OpNe, OpIn, OpNin, OpGt, OpGte, OpLt, OpLte, OpILike}
+
+// Check returns the filters of f the spec rejects, ordered by parameter
+func (s *FilterSpec) Check(f Filter) ParamErrors {
+	_, errs := s.conds(f)
+	return errs
+}
+
+// Conds builds the conditions of f, empty values are skipped
+// The columns come from the spec, the values are bound, so clients can't change the query
+func (s *FilterSpec) Conds(f Filter) ([]Conds, error) {
+	conds, errs := s.conds(f)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return conds, nil
+}
+
+func (s *FilterSpec) conds(f Filter) ([]Conds, ParamErrors) {
+	var (
+		conds []Conds
+		errs  ParamErrors
+	)
+	for _, name := range sortedKeys(f) {
+		field, ok := s.fields[name]
+		for _, op := range sortedKeys(f[name]) {
+			value := f[name][op]
+			param := fmt.Sprintf("filter[%s][%s]", name, op)
+			switch {
+			case !ok:
+				errs = append(errs, &ParamError{Field: param, Value: value, Tag: TagFilterField, Param: s.names})
+				continue
+			case !slices.Contains(field.ops, op):
+				errs = append(errs, &ParamError{Field: param, Value: value, Tag: TagFilterOp, Param: strings.Join(field.ops, " ")})
+				continue
+			case value == "":
+				continue
+			}
+			cond, err := field.cond(op, value)
+			if err != nil {
+				err.Field = param
+				errs = append(errs, err)
+				continue
+			}
+			conds = append(conds, cond)
+		}
+	}
+	return conds, errs
+}
+
+func (f FilterField) cond(op, value string) (Conds, *ParamError) {
+	if op == OpIn || op == OpNin {
+		raw := strings.Split(value, ",")
+		if len(raw) > MaxFilterValues {
+			return Conds{}, &ParamError{Value: value, Tag: TagFilterMax, Param: strconv.Itoa(MaxFilterValues)}
+		}
+		values := make([]any, 0, len(raw))
+		for _, r := range raw {
+			if r = strings.TrimSpace(r); r == "" {
+				continue
+			}
+			v, err := f.value(r)
+			if err != nil {
+				return Conds{}, err
+			}
+			values = append(values, v)
+		}
+		if op == OpIn {
+			return In(f.column, values), nil
+		}
+		return NotIn(f.column, values), nil
+	}
+	if op == OpILike {
+		if f.jsonKey != "" {
+			return JsonbFieldILike(f.column, f.jsonKey, value), nil
+		}
+		return ILike(f.column, value), nil
+	}
+	v, err := f.value(value)
+	if err != nil {
+		return Conds{}, err
+	}
+	if f.jsonKey != "" {
+		switch op {
+		case OpGte:
+			return JsonbNumericMin(f.column, f.jsonKey, v), nil
+		case OpLte:
+			return JsonbNumericMax(f.column, f.jsonKey, v), nil
+		}
+		// ->> returns text, so equality compares the checked raw value
+		return JsonbField(f.column, f.jsonKey, value), nil
+	}
+	switch op {
+	case OpNe:
+		return NotEq(f.column, v), nil
+	case OpGt:
+		return Gt(f.column, v), nil
+	case OpGte:
+		return Min(f.column, v), nil
+	case OpLt:
+		return Lt(f.column, v), nil
+	case OpLte:
+		return Max(f.column, v), nil
+	}
+	return Eq(f.column, v), nil
+}
+
+// value coerces a raw value to the type of the field
+func (f FilterField) value(raw string) (any, *ParamError) {
+	if len(f.values) > 0 && !slices.Contains(f.values, raw) {
+		return nil, &ParamError{Value: raw, Tag: TagFilterValue, Param: strings.Join(f.values, " ")}
+	}
+	v, err := f.parse(raw)
+	if err != nil {
+		return nil, &ParamError{Value: raw, Tag: TagFilterType, Param: f.typeName}
+	}
+	return v, nil
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	uuidType            = reflect.TypeOf(uuid.UUID{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// filterParser returns the name of t in the errors and the function coercing values to it
+func filterParser(t reflect.Type) (string, func(string) (any, error)) {
+	switch t {
+	case timeType:
+		return "date-time", parseFilterTime
+	case uuidType:
+		return "uuid", func(s string) (any, error) { return uuid.Parse(s) }
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string", func(s string) (any, error) { return s, nil }
+	case reflect.Bool:
+		return "boolean", func(s string) (any, error) { return strconv.ParseBool(s) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer", func(s string) (any, error) { return strconv.ParseInt(s, 10, t.Bits()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", func(s string) (any, error) { return strconv.ParseUint(s, 10, t.Bits()) }
+	case reflect.Float32, reflect.Float64:
+		return "number", func(s string) (any, error) { return strconv.ParseFloat(s, t.Bits()) }
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return "string", func(s string) (any, error) {
+			v := reflect.New(t)
+			if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return nil, err
+			}
+			return v.Elem().Interface(), nil
+		}
+	}
+	panic(fmt.Sprintf("query: %s values can't be filtered", t))
+}
+
+func parseFilterTime(s string) (any, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
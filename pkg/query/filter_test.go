@@ -0,0 +1,104 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type testStatus string
+
+var testSpec = NewFilterSpec(
+	FilterOn[uuid.UUID]("id", OpEq, OpIn),
+	FilterOn[testStatus]("status", OpEq, OpIn, OpNin).Values("open", "done"),
+	FilterOn[string]("title", OpILike),
+	FilterOn[int]("score", OpGt, OpLte).Column("points"),
+	FilterOn[time.Time]("created_at", OpGte),
+	FilterOn[string]("city", OpEq).Column("address").JSONKey("city"),
+)
+
+func TestParseFilter(t *testing.T) {
+	values, _ := url.ParseQuery("filter[status]=open&filter[status][in]=open&filter[status][in]=done&filter[title][ilike]=go&page=2&filter=x&filter[a][b][c]=1")
+	want := Filter{
+		"status": {"eq": "open", "in": "open,done"},
+		"title":  {"ilike": "go"},
+	}
+	if got := ParseFilter(values); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFilter() = %v, want %v", got, want)
+	}
+}
+
+func TestParseFilterEqPriority(t *testing.T) {
+	for _, raw := range []string{
+		"filter[status]=open&filter[status][eq]=done",
+		"filter[status][eq]=done&filter[status]=open",
+	} {
+		values, _ := url.ParseQuery(raw)
+		// the map order of values changes between runs, parse a few times
+		for i := 0; i < 20; i++ {
+			if got := ParseFilter(values)["status"]["eq"]; got != "done" {
+				t.Fatalf("ParseFilter(%q) eq = %q, want the explicit done", raw, got)
+			}
+		}
+	}
+}
+
+func TestFilterSpecConds(t *testing.T) {
+	id := uuid.New()
+	conds, err := testSpec.Conds(Filter{
+		"id":         {"in": id.String() + ", "},
+		"status":     {"nin": "open,done"},
+		"title":      {"ilike": "go"},
+		"score":      {"gt": "3", "lte": ""},
+		"created_at": {"gte": "2026-01-01"},
+		"city":       {"eq": "Izmir"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, values := Build(conds)
+	wantQuery := "address->>'city' = ? AND created_at >= ? AND id IN (?) AND points > ? AND status NOT IN (?,?) AND title ILIKE ?"
+	wantValues := []any{"Izmir", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), id, int64(3), "open", "done", "%go%"}
+	if query != wantQuery || !reflect.DeepEqual(values, wantValues) {
+		t.Errorf("Build() = %q %v, want %q %v", query, values, wantQuery, wantValues)
+	}
+}
+
+func TestFilterSpecErrors(t *testing.T) {
+	_, err := testSpec.Conds(Filter{
+		"secret": {"eq": "1"},
+		"title":  {"eq": "go"},
+		"score":  {"gt": "many"},
+		"status": {"in": "open,lost"},
+	})
+	var errs ParamErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Conds() = %v, want ParamErrors", err)
+	}
+	got := make(map[string]string, len(errs))
+	for _, e := range errs {
+		got[e.Field] = e.Tag
+	}
+	want := map[string]string{
+		"filter[secret][eq]": TagFilterField,
+		"filter[title][eq]":  TagFilterOp,
+		"filter[score][gt]":  TagFilterType,
+		"filter[status][in]": TagFilterValue,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+}
+
+func TestNewFilterSpecPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewFilterSpec() accepted nin on a JSONB key")
+		}
+	}()
+	NewFilterSpec(FilterOn[string]("city", OpNin).Column("address").JSONKey("city"))
+}
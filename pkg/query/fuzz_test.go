@@ -0,0 +1,352 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// builderCase builds SQL from untrusted input: a string, an int and a float
+type builderCase struct {
+	name  string
+	build func(s string, n int, x float64) (sql string, values []any, skip bool)
+}
+
+func cond(c Conds) (string, []any, bool) {
+	return c.Key, c.Values, c.Skip || !c.active()
+}
+
+func sortCond(c SortCond) (string, []any, bool) {
+	return c.Expr(), c.Values, c.Skip
+}
+
+// dryRunDialector writes the placeholders as ? like the builders, so the SQL of a scope is shaped like theirs
+type dryRunDialector struct {
+	postgres.Dialector
+}
+
+func (dryRunDialector) BindVarTo(w clause.Writer, _ *gorm.Statement, _ any) {
+	_ = w.WriteByte('?')
+}
+
+// fuzzDB builds the SQL of scopes without a database
+var fuzzDB = func() *gorm.DB {
+	db, err := gorm.Open(dryRunDialector{postgres.Dialector{Config: &postgres.Config{DSN: "host=localhost"}}}, &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return db
+}()
+
+// scope returns the SQL of a query on todos using scope
+func scope(s func(db *gorm.DB) *gorm.DB) (string, []any, bool) {
+	stmt := fuzzDB.Table("todos").Scopes(s).Find(&[]map[string]any{}).Statement
+	return stmt.SQL.String(), stmt.Vars, false
+}
+
+var fuzzSpec = NewFilterSpec(
+	FilterOn[string]("title", OpEq, OpNe, OpIn, OpNin, OpILike),
+	FilterOn[int]("count", OpGt, OpGte, OpLt, OpLte, OpIn),
+	FilterOn[string]("city", OpEq, OpILike).Column("address").JSONKey("city"),
+	FilterOn[float64]("point", OpGte, OpLte).Column("review").JSONKey("point"),
+)
+
+var fuzzSortSpec = NewSortSpec("-x", SortOn("x"))
+
+// benignInput is the benign input of the builders rejecting x
+var benignInput = map[string]string{
+	"FilterIntIn":      "1",
+	"FilterJSONNumber": "1.5",
+}
+
+func filterCond(field, op string) func(s string, _ int, _ float64) (string, []any, bool) {
+	return func(s string, _ int, _ float64) (string, []any, bool) {
+		conds, err := fuzzSpec.Conds(Filter{field: {op: s}})
+		if err != nil || len(conds) != 1 {
+			return "", nil, true
+		}
+		return cond(conds[0])
+	}
+}
+
+// builderCases cover every exported builder, the untrusted string is passed as every column, JSON key and value
+// Text, TextPrefix and Custom write their SQL as it is, so only their values are untrusted
+var builderCases = []builderCase{
+	{"IntToBool", func(s string, n int, _ float64) (string, []any, bool) { return cond(IntToBool(s, n)) }},
+	{"Like", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Like(s, s)) }},
+	{"ILike", func(s string, _ int, _ float64) (string, []any, bool) { return cond(ILike(s, s)) }},
+	{"ILikeMulti", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(ILikeMulti([]string{s, "title"}, s))
+	}},
+	{"ILikeMultiValues", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(ILikeMultiValues([]string{s, "title"}, []string{s}))
+	}},
+	{"IntGreaterOrEqual", func(s string, n int, _ float64) (string, []any, bool) { return cond(IntGreaterOrEqual(s, n)) }},
+	{"Geo", func(s string, _ int, x float64) (string, []any, bool) { return cond(Geo(s, x, x, x)) }},
+	{"OrderGeo", func(s string, _ int, x float64) (string, []any, bool) {
+		sql, values := OrderGeo(s, x, x)
+		return sql, values, false
+	}},
+	{"Text", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Text("title", s)) }},
+	{"TextPrefix", func(s string, _ int, _ float64) (string, []any, bool) { return cond(TextPrefix("title", s)) }},
+	{"Eq", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Eq(s, s)) }},
+	{"StrArr", func(s string, _ int, _ float64) (string, []any, bool) { return cond(StrArr(s, []string{s, "a"})) }},
+	{"Custom", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Custom("title = ?", s)) }},
+	{"NotEq", func(s string, _ int, _ float64) (string, []any, bool) { return cond(NotEq(s, s)) }},
+	{"In", func(s string, _ int, _ float64) (string, []any, bool) { return cond(In(s, strings.Split(s, ","))) }},
+	{"InSeperated", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(InSeperated(s, strings.Split(s, ",")))
+	}},
+	{"Min", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Min(s, s)) }},
+	{"Max", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Max(s, s)) }},
+	{"Gt", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Gt(s, s)) }},
+	{"Lt", func(s string, _ int, _ float64) (string, []any, bool) { return cond(Lt(s, s)) }},
+	{"NotNull", func(s string, _ int, _ float64) (string, []any, bool) { return cond(NotNull(s, false)) }},
+	{"NotIn", func(s string, _ int, _ float64) (string, []any, bool) { return cond(NotIn(s, strings.Split(s, ","))) }},
+	{"NotInSeperated", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(NotInSeperated(s, strings.Split(s, ",")))
+	}},
+	{"ArrayContains", func(s string, _ int, _ float64) (string, []any, bool) { return cond(ArrayContains(s, s)) }},
+	{"ArrayEquals", func(s string, _ int, _ float64) (string, []any, bool) { return cond(ArrayEquals(s, []string{s})) }},
+	{"JsonbField", func(s string, _ int, _ float64) (string, []any, bool) { return cond(JsonbField(s, s, s)) }},
+	{"JsonbFieldNullSafe", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(JsonbFieldNullSafe(s, s, s))
+	}},
+	{"JsonbFieldILike", func(s string, _ int, _ float64) (string, []any, bool) { return cond(JsonbFieldILike(s, s, s)) }},
+	{"JsonbFieldILikeNullSafe", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(JsonbFieldILikeNullSafe(s, s, s))
+	}},
+	{"JsonbNestedField", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(JsonbNestedField(s, []string{s, s}, s))
+	}},
+	{"JsonbNestedFieldILike", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(JsonbNestedFieldILike(s, []string{s, s}, s))
+	}},
+	{"JsonbMultiFieldsILike", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(JsonbMultiFieldsILike(s, [][]string{{s}, {s, s}}, s))
+	}},
+	{"JsonbArrayOverlap", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(JsonbArrayOverlap(s, s, []string{s}))
+	}},
+	{"JsonbAgeRange", func(s string, n int, _ float64) (string, []any, bool) { return cond(JsonbAgeRange(s, n)) }},
+	{"BuildPersonalizationScore", func(s string, n int, _ float64) (string, []any, bool) {
+		sql, values := BuildPersonalizationScore(s, []PersonalizationScoreField{
+			{Type: "interest", Value: []string{s}, Points: n},
+			{Type: "gender", Value: s, Points: n},
+			{Type: "age", Value: 30, Points: n},
+		})
+		return sql, values, s == ""
+	}},
+	{"JsonbNumericMin", func(s string, _ int, x float64) (string, []any, bool) { return cond(JsonbNumericMin(s, s, x)) }},
+	{"JsonbNumericMax", func(s string, _ int, x float64) (string, []any, bool) { return cond(JsonbNumericMax(s, s, x)) }},
+	{"StrArrWithPrefix", func(s string, _ int, _ float64) (string, []any, bool) {
+		return cond(StrArrWithPrefix(s, []string{s}, s))
+	}},
+	{"And", func(s string, _ int, _ float64) (string, []any, bool) {
+		sql, values, _ := cond(And(Eq("status", "a"), Or(ILike(s, s), Not(JsonbField(s, s, "a")))))
+		return sql, values, s == ""
+	}},
+	{"SortBasic", func(s string, _ int, _ float64) (string, []any, bool) {
+		return sortCond(SortBasic(s, "-"+s, false))
+	}},
+	{"SortGeo", func(s string, _ int, x float64) (string, []any, bool) {
+		return sortCond(SortGeo(s, &x, &x, false))
+	}},
+	{"SortSpec", func(s string, _ int, _ float64) (string, []any, bool) {
+		conds, err := fuzzSortSpec.Conds(s)
+		if err != nil {
+			return "", nil, true
+		}
+		return sortCond(conds[0])
+	}},
+	{"SortDirect", func(s string, _ int, _ float64) (string, []any, bool) { return scope(SortDirect(s, true)) }},
+	{"Sort", func(s string, _ int, x float64) (string, []any, bool) {
+		return scope(Sort([]SortCond{SortBasic(s, SortDesc, true), SortGeo(s, &x, &x, false, true)}))
+	}},
+	{"OrderBy", func(s string, _ int, x float64) (string, []any, bool) {
+		return scope(OrderBy([]SortCond{SortBasic(s, SortDesc, false), SortGeo(s, &x, &x, false)}))
+	}},
+	{"After", func(s string, n int, _ float64) (string, []any, bool) {
+		sql, values := After([]SortCond{{Key: s, Direction: SortDesc}, {Key: KeyID, Direction: SortDesc}}, []any{s, n}, false)
+		return sql, values, false
+	}},
+	{"AfterMixed", func(s string, n int, _ float64) (string, []any, bool) {
+		sql, values := After([]SortCond{{Key: s, Direction: SortDesc}, {Key: KeyID, Direction: SortAsc}}, []any{s, n}, true)
+		return sql, values, false
+	}},
+	{"SortKeyset", func(s string, _ int, _ float64) (string, []any, bool) {
+		return scope(SortKeyset([]SortCond{{Key: s, Direction: SortDesc}, {Key: KeyID, Direction: SortDesc}}, true))
+	}},
+	{"Build", func(s string, n int, _ float64) (string, []any, bool) {
+		sql, values := Build([]Conds{
+			ILikeMulti([]string{s, "title"}, s),
+			Eq(s, s),
+			{Key: "LIMIT ?", Values: V[any]{n}},
+		})
+		return sql, values, s == ""
+	}},
+	{"Apply", func(s string, _ int, _ float64) (string, []any, bool) {
+		sql, values, _ := scope(Apply([]Conds{ILikeMulti([]string{s, "title"}, s), Eq(s, s)}, OR))
+		return sql, values, s == ""
+	}},
+	{"FilterEq", filterCond("title", OpEq)},
+	{"FilterNin", filterCond("title", OpNin)},
+	{"FilterILike", filterCond("title", OpILike)},
+	{"FilterIntIn", filterCond("count", OpIn)},
+	{"FilterJSONKey", filterCond("city", OpEq)},
+	{"FilterJSONKeyILike", filterCond("city", OpILike)},
+	{"FilterJSONNumber", filterCond("point", OpGte)},
+}
+
+// FuzzBuilders checks untrusted input only reaches the SQL of the builders quoted or bound:
+// the SQL has the shape of the SQL built from benign input, and one placeholder per value
+func FuzzBuilders(f *testing.F) {
+	for _, seed := range []string{
+		"x", "", "title", "todos.id", "order", "Title", "a b", "a'b", `a"b`, "a?b", `a\b`, `a\'b`, "a\x00b",
+		"x; DROP TABLE todos; --", "x' OR '1'='1", `x" OR "1"="1`, "a) OR (1=1", "1,2,3", "$1", "$$x$$",
+		"E'x'", `U&"x"`, "/* x */", "-- x", "current_user", "null", "a->>'b'", "ñ", "\xff",
+	} {
+		f.Add(seed, 1, 1.5)
+	}
+	f.Fuzz(func(t *testing.T, s string, n int, x float64) {
+		for _, c := range builderCases {
+			input, ok := benignInput[c.name]
+			if !ok {
+				input = "x"
+			}
+			benign, _, skip := c.build(input, 1, 1)
+			if skip {
+				t.Fatalf("%s: benign input is skipped", c.name)
+			}
+			want, err := sqlShape(benign)
+			if err != nil {
+				t.Fatalf("%s: %q: %v", c.name, benign, err)
+			}
+			sql, values, skip := c.build(s, n, x)
+			if skip {
+				continue
+			}
+			got, err := sqlShape(sql)
+			if err != nil {
+				t.Fatalf("%s(%q): %q: %v", c.name, s, sql, err)
+			}
+			if got != want {
+				t.Fatalf("%s(%q) = %q, shaped %q, want %q", c.name, s, sql, got, want)
+			}
+			if marks := strings.Count(sql, "?"); marks != len(values) {
+				t.Fatalf("%s(%q) = %q has %d placeholders for %d values", c.name, s, sql, marks, len(values))
+			}
+		}
+	})
+}
+
+// placeholderList matches the placeholders of an IN list or an array, their count follows the input
+var placeholderList = regexp.MustCompile(`\?(,\s*\?)+`)
+
+// sqlShape reduces sql to its shape: string literals are L, identifiers and key words W and numbers N
+// Anything that could end the statement or start a comment outside of quotes is an error
+func sqlShape(sql string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '\'':
+			end, err := quoteEnd(sql, i+1, '\'', false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte('L')
+			i = end
+		case c == '"':
+			end, err := quoteEnd(sql, i+1, '"', false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte('W')
+			i = end
+		case (c == 'E' || c == 'e') && strings.HasPrefix(sql[i+1:], "'"):
+			end, err := quoteEnd(sql, i+2, '\'', true)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte('L')
+			i = end
+		case (c == 'U' || c == 'u') && strings.HasPrefix(sql[i+1:], `&"`):
+			end, err := quoteEnd(sql, i+3, '"', false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte('W')
+			i = end
+		case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			for i < len(sql) && (sql[i] == '_' || sql[i] == '$' || 'a' <= sql[i] && sql[i] <= 'z' ||
+				'A' <= sql[i] && sql[i] <= 'Z' || '0' <= sql[i] && sql[i] <= '9') {
+				i++
+			}
+			b.WriteByte('W')
+		case '0' <= c && c <= '9':
+			for i < len(sql) && '0' <= sql[i] && sql[i] <= '9' {
+				i++
+			}
+			b.WriteByte('N')
+		case c == ';' || c == '$' || strings.HasPrefix(sql[i:], "--") || strings.HasPrefix(sql[i:], "/*"):
+			return "", fmt.Errorf("unquoted %q at %d", sql[i:], i)
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			for i < len(sql) && strings.IndexByte(" \t\n\r", sql[i]) >= 0 {
+				i++
+			}
+			b.WriteByte(' ')
+		case c < 0x20 || c >= 0x7f:
+			return "", fmt.Errorf("unquoted byte %#x at %d", c, i)
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	shape := b.String()
+	for strings.Contains(shape, "W.W") {
+		shape = strings.ReplaceAll(shape, "W.W", "W")
+	}
+	return placeholderList.ReplaceAllString(shape, "?"), nil
+}
+
+// quoteEnd returns the index after the quote closing the quoted text starting at i, doubled quotes are escaped
+func quoteEnd(sql string, i int, quote byte, backslash bool) (int, error) {
+	for i < len(sql) {
+		switch {
+		case backslash && sql[i] == '\\':
+			i += 2
+		case sql[i] == quote && i+1 < len(sql) && sql[i+1] == quote:
+			i += 2
+		case sql[i] == quote:
+			return i + 1, nil
+		default:
+			i++
+		}
+	}
+	return 0, fmt.Errorf("unterminated %c quote in %q", quote, sql)
+}
+
+func TestBuildersShape(t *testing.T) {
+	for _, c := range builderCases {
+		input, ok := benignInput[c.name]
+		if !ok {
+			input = "x"
+		}
+		sql, _, skip := c.build(input, 1, 1)
+		if skip {
+			t.Errorf("%s: benign input is skipped", c.name)
+		}
+		if _, err := sqlShape(sql); err != nil {
+			t.Errorf("%s: %q: %v", c.name, sql, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package query
+
+import "strings"
+
+// And joins conds with AND into one condition, groups nest:
+//
+//	query.And(
+//		query.Eq("status", status),
+//		query.Or(query.ILike("title", q), query.ILike("description", q)),
+//	)
+//
+// builds "(status = ? AND (title ILIKE ? OR description ILIKE ?))" with the values in placeholder order.
+// The conds Build would drop are dropped, a group without conds left is skipped, so empty parameters vanish from the tree.
+// The result is a Conds, use it with Apply, Build or another group.
+func And(conds ...Conds) Conds {
+	return group(AND, conds)
+}
+
+// Or joins conds with OR into one condition, see And
+func Or(conds ...Conds) Conds {
+	return group(OR, conds)
+}
+
+// Not negates cond, it is skipped when cond is
+func Not(cond Conds) Conds {
+	if !cond.active() {
+		return skipCond()
+	}
+	return Conds{
+		Key:    "NOT " + paren(cond.Key),
+		Values: cond.Values,
+	}
+}
+
+func group(op string, conds []Conds) Conds {
+	var (
+		keys   []string
+		values V[any]
+	)
+	for _, cond := range conds {
+		if !cond.active() {
+			continue
+		}
+		keys = append(keys, paren(cond.Key))
+		values = append(values, cond.Values...)
+	}
+	switch len(keys) {
+	case 0:
+		return skipCond()
+	case 1:
+		return Conds{Key: keys[0], Values: values}
+	}
+	return Conds{
+		Key:    "(" + strings.Join(keys, " "+op+" ") + ")",
+		Values: values,
+	}
+}
+
+// active reports whether Build keeps the condition: not skipped, and with a first value or no placeholder
+func (c Conds) active() bool {
+	if c.Skip || c.Key == "" {
+		return false
+	}
+	if len(c.Values) > 0 {
+		return c.Values[0] != ""
+	}
+	return !strings.Contains(c.Key, "?")
+}
+
+// paren wraps key in parentheses when it joins conditions with AND or OR outside of them
+func paren(key string) string {
+	if !joined(key) {
+		return key
+	}
+	return "(" + key + ")"
+}
+
+// joined reports whether key has an AND or OR outside parentheses and quoted literals or identifiers
+func joined(key string) bool {
+	var (
+		depth int
+		quote byte
+	)
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isSpace(c):
+			for _, op := range []string{AND, OR} {
+				end := i + 1 + len(op)
+				if end < len(key) && strings.EqualFold(key[i+1:end], op) && isSpace(key[end]) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
@@ -0,0 +1,104 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		cond       Conds
+		wantKey    string
+		wantValues []any
+		wantSkip   bool
+	}{
+		{
+			name:       "and with a nested or",
+			cond:       And(Eq("status", "pending"), Or(ILike("title", "milk"), ILike("description", "milk"))),
+			wantKey:    "(status = ? AND (title ILIKE ? OR description ILIKE ?))",
+			wantValues: []any{"pending", "%milk%", "%milk%"},
+		},
+		{
+			name:       "skipped conds are dropped",
+			cond:       And(Eq("status", "pending"), Or(ILike("title", ""), ILike("description", ""))),
+			wantKey:    "status = ?",
+			wantValues: []any{"pending"},
+		},
+		{
+			name:       "empty first value is dropped like Build does",
+			cond:       Or(Eq("status", ""), Min("age", 3)),
+			wantKey:    "age >= ?",
+			wantValues: []any{3},
+		},
+		{
+			name:     "every cond skipped",
+			cond:     Or(ILike("title", ""), And(Eq("status", nil))),
+			wantSkip: true,
+		},
+		{
+			name:     "no conds",
+			cond:     And(),
+			wantSkip: true,
+		},
+		{
+			name:       "raw keys joining conds are parenthesized",
+			cond:       And(ILikeMulti([]string{"title", "description"}, "a"), NotNull("deleted_at", false)),
+			wantKey:    "((title ILIKE ? OR description ILIKE ?) AND deleted_at IS NOT NULL)",
+			wantValues: []any{"%a%", "%a%"},
+		},
+		{
+			name:       "a single joined cond keeps its parentheses",
+			cond:       Or(ILikeMulti([]string{"title", "description"}, "a")),
+			wantKey:    "(title ILIKE ? OR description ILIKE ?)",
+			wantValues: []any{"%a%", "%a%"},
+		},
+		{
+			name:       "operators in literals and groups aren't split",
+			cond:       And(Custom("note = 'a OR b'", 1), Custom("(a = ? OR b = ?)", 2)),
+			wantKey:    "(note = 'a OR b' AND (a = ? OR b = ?))",
+			wantValues: []any{1, 2},
+		},
+		{
+			name:       "not",
+			cond:       Not(Or(Eq("a", 1), And(Eq("b", 2), Eq("c", 3)))),
+			wantKey:    "NOT (a = ? OR (b = ? AND c = ?))",
+			wantValues: []any{1, 2, 3},
+		},
+		{
+			name:     "not of a skipped cond",
+			cond:     Not(ILike("title", "")),
+			wantSkip: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.cond.Skip != tt.wantSkip {
+				t.Fatalf("Skip = %v, want %v", tt.cond.Skip, tt.wantSkip)
+			}
+			if tt.wantSkip {
+				return
+			}
+			if tt.cond.Key != tt.wantKey {
+				t.Errorf("Key = %q, want %q", tt.cond.Key, tt.wantKey)
+			}
+			if !reflect.DeepEqual([]any(tt.cond.Values), tt.wantValues) {
+				t.Errorf("Values = %v, want %v", tt.cond.Values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestBuildGroups(t *testing.T) {
+	q, values := Build([]Conds{
+		Eq("status", "pending"),
+		Or(ILike("title", "milk"), ILike("description", "milk")),
+		Not(In("id", []int{})),
+	})
+	if want := "status = ? AND (title ILIKE ? OR description ILIKE ?)"; q != want {
+		t.Errorf("Build() = %q, want %q", q, want)
+	}
+	if want := []any{"pending", "%milk%", "%milk%"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Build() values = %v, want %v", values, want)
+	}
+}
@@ -0,0 +1,105 @@
+package query
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxIdentLen is the length PostgreSQL truncates identifiers to
+const MaxIdentLen = 63
+
+// identPattern matches the plain and table qualified columns written without quotes
+var identPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// ValidIdent reports whether s is a column or a table qualified column that can be written as it is, e.g. todos.created_at
+// Reserved words like user or order have to be quoted, so they aren't valid
+func ValidIdent(s string) bool {
+	if !identPattern.MatchString(s) {
+		return false
+	}
+	for _, part := range strings.Split(s, ".") {
+		if len(part) > MaxIdentLen || reserved[strings.ToLower(part)] {
+			return false
+		}
+	}
+	return true
+}
+
+// QuoteIdent quotes s as one identifier, so it can't be read as anything else
+// Question marks and backslashes are written as unicode escapes, GORM would take a ? for a placeholder even in quotes
+// NUL bytes are dropped, PostgreSQL doesn't allow them
+func QuoteIdent(s string) string {
+	s = strings.ReplaceAll(s, "\x00", "")
+	if !strings.ContainsAny(s, `?\`) {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return `U&"` + unicodeEscaper.Replace(s) + `"`
+}
+
+// QuoteLiteral quotes s as a string literal, escaping it like QuoteIdent
+func QuoteLiteral(s string) string {
+	s = strings.ReplaceAll(s, "\x00", "")
+	if !strings.ContainsAny(s, `?\`) {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return "E'" + literalEscaper.Replace(s) + "'"
+}
+
+var (
+	unicodeEscaper = strings.NewReplacer(`"`, `""`, `\`, `\\`, `?`, `\003F`)
+	literalEscaper = strings.NewReplacer(`'`, `''`, `\`, `\\`, `?`, `\x3f`)
+)
+
+// column writes the column k of a builder, quoting what ValidIdent rejects so it can't extend the query
+// Expressions are written with Custom
+func column(k string) string {
+	if ValidIdent(k) {
+		return k
+	}
+	if !identPattern.MatchString(k) {
+		return QuoteIdent(k)
+	}
+	parts := strings.Split(k, ".")
+	for i, part := range parts {
+		if !ValidIdent(part) {
+			parts[i] = QuoteIdent(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// jsonPath writes the JSON path of k, the segments are quoted literals and the last one is read as text
+// e.g. config->'translation'->'tr'->>'title'
+func jsonPath(k string, path ...string) string {
+	var b strings.Builder
+	b.WriteString(column(k))
+	for i, segment := range path {
+		if i == len(path)-1 {
+			b.WriteString("->>")
+		} else {
+			b.WriteString("->")
+		}
+		b.WriteString(QuoteLiteral(segment))
+	}
+	return b.String()
+}
+
+// reserved are the reserved key words of PostgreSQL, which can't be column names without quotes
+var reserved = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true, "asc": true,
+	"asymmetric": true, "authorization": true, "binary": true, "both": true, "case": true, "cast": true, "check": true,
+	"collate": true, "collation": true, "column": true, "concurrently": true, "constraint": true, "create": true,
+	"cross": true, "current_catalog": true, "current_date": true, "current_role": true, "current_schema": true,
+	"current_time": true, "current_timestamp": true, "current_user": true, "default": true, "deferrable": true,
+	"desc": true, "distinct": true, "do": true, "else": true, "end": true, "except": true, "false": true, "fetch": true,
+	"for": true, "foreign": true, "freeze": true, "from": true, "full": true, "grant": true, "group": true,
+	"having": true, "ilike": true, "in": true, "initially": true, "inner": true, "intersect": true, "into": true,
+	"is": true, "isnull": true, "join": true, "lateral": true, "leading": true, "left": true, "like": true,
+	"limit": true, "localtime": true, "localtimestamp": true, "natural": true, "not": true, "notnull": true,
+	"null": true, "offset": true, "on": true, "only": true, "or": true, "order": true, "outer": true,
+	"overlaps": true, "placing": true, "primary": true, "references": true, "returning": true, "right": true,
+	"select": true, "session_user": true, "similar": true, "some": true, "symmetric": true, "system_user": true,
+	"table": true, "tablesample": true, "then": true, "to": true, "trailing": true, "true": true, "union": true,
+	"unique": true, "user": true, "using": true, "variadic": true, "verbose": true, "when": true, "where": true,
+	"window": true, "with": true,
+}
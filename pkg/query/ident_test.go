@@ -0,0 +1,100 @@
+package query
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidIdent(t *testing.T) {
+	tests := map[string]bool{
+		"title":                     true,
+		"todos.created_at":          true,
+		"_x1":                       true,
+		"":                          false,
+		"1x":                        false,
+		"a.b.c":                     false,
+		"a b":                       false,
+		"title; DROP TABLE todos":   false,
+		"order":                     false,
+		"todos.USER":                false,
+		strings.Repeat("a", 63):     true,
+		strings.Repeat("a", 64):     false,
+		"a->>'b'":                   false,
+		`"title"`:                   false,
+		"title--":                   false,
+		"ñ":                         false,
+		"current_user":              false,
+		"todos.current_timestamp_x": true,
+	}
+	for s, want := range tests {
+		if got := ValidIdent(s); got != want {
+			t.Errorf("ValidIdent(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in, ident, literal string
+	}{
+		{"title", `"title"`, `'title'`},
+		{`a"b`, `"a""b"`, `'a"b'`},
+		{"a'b", `"a'b"`, `'a''b'`},
+		{"a?b", `U&"a\003Fb"`, `E'a\x3fb'`},
+		{`a\b`, `U&"a\\b"`, `E'a\\b'`},
+		{`a\'b"`, `U&"a\\'b"""`, `E'a\\''b"'`},
+		{"a\x00b", `"ab"`, `'ab'`},
+	}
+	for _, tt := range tests {
+		if got := QuoteIdent(tt.in); got != tt.ident {
+			t.Errorf("QuoteIdent(%q) = %s, want %s", tt.in, got, tt.ident)
+		}
+		if got := QuoteLiteral(tt.in); got != tt.literal {
+			t.Errorf("QuoteLiteral(%q) = %s, want %s", tt.in, got, tt.literal)
+		}
+	}
+}
+
+func TestBuilderQuoting(t *testing.T) {
+	tests := []struct {
+		name string
+		cond Conds
+		want string
+	}{
+		{"plain column", Eq("todos.title", "a"), "todos.title = ?"},
+		{"reserved column", Eq("todos.order", 1), `todos."order" = ?`},
+		{"injected column", Eq("id = 1 OR 1", 1), `"id = 1 OR 1" = ?`},
+		{"json key", JsonbField("address", "city", "a"), "address->>'city' = ?"},
+		{"injected json key", JsonbField("address", "x' OR '1'='1", "a"), "address->>'x'' OR ''1''=''1' = ?"},
+		{"json path", JsonbNestedField("config", []string{"tr", "title?"}, "a"), `config->'tr'->>E'title\x3f' = ?`},
+		{"array overlap", JsonbArrayOverlap("audience", "interest", []string{"a", "", "b"}),
+			"jsonb_exists_any(audience->'interest', ARRAY[?,?]::text[])"},
+		{"age range", JsonbAgeRange("audience", 25),
+			"((audience->'age_range'->>0)::int <= ? AND (audience->'age_range'->>1)::int >= ?)"},
+		{"prefixed array", StrArrWithPrefix("tags", []string{"a'b"}, "#"), "tags && ARRAY[?]::text[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.cond.Key != tt.want {
+				t.Errorf("Key = %s, want %s", tt.cond.Key, tt.want)
+			}
+			if marks := strings.Count(tt.cond.Key, "?"); marks != len(tt.cond.Values) {
+				t.Errorf("%d placeholders for %d values", marks, len(tt.cond.Values))
+			}
+		})
+	}
+}
+
+func TestBuildLimit(t *testing.T) {
+	q, values := Build([]Conds{
+		{Key: "LIMIT ?", Values: V[any]{10}},
+		Eq("a", 1),
+		JsonbField("meta", "LIMIT", "x"),
+	})
+	if want := "a = ? AND meta->>'LIMIT' = ? LIMIT ?"; q != want {
+		t.Errorf("Build() = %q, want %q", q, want)
+	}
+	if len(values) != 3 || values[2] != 10 {
+		t.Errorf("Build() values = %v", values)
+	}
+}
@@ -0,0 +1,125 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// KeyID is the unique key ending every keyset order
+const KeyID = "id"
+
+// Keyset returns the order of a keyset page: the conds not skipped, ending with id so every row has its own position
+// The keys must be columns without nulls, expressions like SortGeo can't be read back from a row
+func Keyset(conds []SortCond) ([]SortCond, error) {
+	keys := make([]SortCond, 0, len(conds)+1)
+	for _, cond := range conds {
+		if cond.Skip {
+			continue
+		}
+		if !ValidIdent(cond.Key) {
+			return nil, fmt.Errorf("query: keyset key %q is not a column", cond.Key)
+		}
+		if cond.Nulls != "" {
+			return nil, fmt.Errorf("query: keyset key %q can't hold nulls", cond.Key)
+		}
+		cond.Direction = direction(cond.Direction)
+		keys = append(keys, cond)
+	}
+	return withTiebreaker(keys, KeyID), nil
+}
+
+// KeysetName identifies the order of keys, e.g. created_at DESC,id DESC
+func KeysetName(keys []SortCond) string {
+	exprs := make([]string, len(keys))
+	for i, key := range keys {
+		exprs[i] = key.Expr()
+	}
+	return strings.Join(exprs, ",")
+}
+
+// Column returns the column of a table qualified key, e.g. todos.id is id
+func Column(key string) string {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		return key[i+1:]
+	}
+	return key
+}
+
+// After builds the condition of the rows after values in the order of keys, before them when backward
+// keys come from Keyset and values hold one value per key
+// Keys sharing one direction compare as a row, e.g. (created_at, id) < (?, ?), which the index of the keys serves
+// Mixed directions expand to created_at < ? OR (created_at = ? AND id > ?)
+// The values are bound as given, use db.Where instead of Build which skips empty string values
+func After(keys []SortCond, values []any, backward bool) (string, []any) {
+	if len(keys) == 0 || len(keys) != len(values) {
+		return "", nil
+	}
+	op := func(key SortCond) string {
+		if (key.Direction == SortDesc) != backward {
+			return "<"
+		}
+		return ">"
+	}
+	if sameDirection(keys) {
+		cols := make([]string, len(keys))
+		marks := make([]string, len(keys))
+		for i, key := range keys {
+			cols[i], marks[i] = key.column(), "?"
+		}
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(cols, ", "), op(keys[0]), strings.Join(marks, ", ")), values
+	}
+	var (
+		ors  []string
+		args []any
+	)
+	for i, key := range keys {
+		ands := make([]string, 0, i+1)
+		for j := range i {
+			ands = append(ands, keys[j].column()+" = ?")
+			args = append(args, values[j])
+		}
+		ands = append(ands, fmt.Sprintf("%s %s ?", key.column(), op(key)))
+		args = append(args, values[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// SortKeyset orders by every key, in the reverse order when backward
+func SortKeyset(keys []SortCond, backward bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, key := range keys {
+			if backward {
+				key.Direction = reverse(key.Direction)
+			}
+			db = db.Order(key.Expr())
+		}
+		return db
+	}
+}
+
+func sameDirection(keys []SortCond) bool {
+	for _, key := range keys[1:] {
+		if key.Direction != keys[0].Direction {
+			return false
+		}
+	}
+	return true
+}
+
+// direction normalizes a sort direction, ascending when empty
+func direction(dir string) string {
+	if strings.EqualFold(dir, SortDesc) {
+		return SortDesc
+	}
+	return SortAsc
+}
+
+func reverse(dir string) string {
+	if dir == SortDesc {
+		return SortAsc
+	}
+	return SortDesc
+}
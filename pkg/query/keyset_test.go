@@ -0,0 +1,85 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyset(t *testing.T) {
+	keys, err := Keyset([]SortCond{
+		SortBasic("created_at", "desc", false),
+		SortBasic("title", "", true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := KeysetName(keys); got != "created_at DESC,id DESC" {
+		t.Errorf("Keyset() = %s, want created_at DESC,id DESC", got)
+	}
+
+	keys, _ = Keyset([]SortCond{SortBasic("todos.id", SortAsc, false)})
+	if len(keys) != 1 {
+		t.Errorf("Keyset() = %v, want the qualified id kept as the only key", keys)
+	}
+
+	if _, err := Keyset([]SortCond{SortBasic("lower(title)", SortAsc, false)}); err == nil {
+		t.Error("Keyset() accepted an expression key")
+	}
+}
+
+func TestAfter(t *testing.T) {
+	desc := []SortCond{{Key: "created_at", Direction: SortDesc}, {Key: "id", Direction: SortDesc}}
+	mixed := []SortCond{{Key: "created_at", Direction: SortDesc}, {Key: "title", Direction: SortAsc}, {Key: "id", Direction: SortAsc}}
+	tests := []struct {
+		name     string
+		keys     []SortCond
+		values   []any
+		backward bool
+		want     string
+		wantArgs []any
+	}{
+		{
+			name:     "same direction forward",
+			keys:     desc,
+			values:   []any{"t", "i"},
+			want:     "(created_at, id) < (?, ?)",
+			wantArgs: []any{"t", "i"},
+		},
+		{
+			name:     "same direction backward",
+			keys:     desc,
+			values:   []any{"t", "i"},
+			backward: true,
+			want:     "(created_at, id) > (?, ?)",
+			wantArgs: []any{"t", "i"},
+		},
+		{
+			name:     "mixed directions",
+			keys:     mixed,
+			values:   []any{"t", "", "i"},
+			want:     "((created_at < ?) OR (created_at = ? AND title > ?) OR (created_at = ? AND title = ? AND id > ?))",
+			wantArgs: []any{"t", "t", "", "t", "", "i"},
+		},
+		{
+			name:     "mixed directions backward",
+			keys:     mixed[:2],
+			values:   []any{"t", "x"},
+			backward: true,
+			want:     "((created_at > ?) OR (created_at = ? AND title < ?))",
+			wantArgs: []any{"t", "t", "x"},
+		},
+		{
+			name:   "missing values",
+			keys:   desc,
+			values: []any{"t"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := After(tt.keys, tt.values, tt.backward)
+			if got != tt.want || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("After() = %q %v, want %q %v", got, args, tt.want, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParamError is a filter or sort parameter a spec rejects
+// The validation service reports it like a struct tag error, translated with the validation_<Tag> message
+type ParamError struct {
+	// Field is the query parameter, e.g. filter[status][in]
+	Field string
+	Value string
+	// Tag is one of the TagFilter or TagSort constants and Param its detail, e.g. the allowed operators
+	Tag   string
+	Param string
+}
+
+func (e *ParamError) Error() string {
+	switch e.Tag {
+	case TagFilterField:
+		return fmt.Sprintf("%s is not filterable, the filterable fields are %s", e.Field, e.Param)
+	case TagFilterOp:
+		return fmt.Sprintf("%s has an unknown operator, the operators are %s", e.Field, e.Param)
+	case TagFilterType:
+		return fmt.Sprintf("%s must be a %s", e.Field, e.Param)
+	case TagFilterMax:
+		return fmt.Sprintf("%s accepts at most %s values", e.Field, e.Param)
+	case TagSortField:
+		return fmt.Sprintf("%s is not sortable, the sortable fields are %s", e.Value, e.Param)
+	default:
+		return fmt.Sprintf("%s must be one of %s", e.Field, e.Param)
+	}
+}
+
+// ParamDetail returns the parameter, its value, the tag and its detail, it satisfies validation.ParamError
+func (e *ParamError) ParamDetail() (field, value, tag, param string) {
+	return e.Field, e.Value, e.Tag, e.Param
+}
+
+// ParamErrors are the rejected parameters of a request
+type ParamErrors []*ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ParamChecker checks the filters of Filterer and the sort of Sorter requests against their specs
+// It satisfies validation.ParamChecker, e.g. validation.New(i18n, query.ParamChecker{})
+type ParamChecker struct{}
+
+func (ParamChecker) CheckParams(req any) []error {
+	var errs ParamErrors
+	if f, ok := req.(Filterer); ok {
+		errs = append(errs, f.FilterSpec().Check(f.QueryFilter())...)
+	}
+	if s, ok := req.(Sorter); ok {
+		errs = append(errs, s.SortSpec().Check(s.QuerySort())...)
+	}
+	res := make([]error, len(errs))
+	for i, err := range errs {
+		res[i] = err
+	}
+	return res
+}
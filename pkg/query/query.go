@@ -47,6 +47,8 @@ func Apply(conds []Conds, opr ...string) func(db *gorm.DB) *gorm.DB {
 //	})
 //
 // Build([]Conds) will return a query string and a slice of values that can be used in the QueryContext method.
+// A key joining conditions with AND or OR, e.g. the one of ILikeMulti, is wrapped in parentheses before it is joined.
+// A condition starting with LIMIT is written after the joined ones.
 func Build(conds []Conds, opr ...string) (string, []interface{}) {
 	if len(conds) == 0 {
 		return "", nil
@@ -54,20 +56,18 @@ func Build(conds []Conds, opr ...string) (string, []interface{}) {
 	op := getOption(AND, opr...)
 	var query string
 	var values []interface{}
-	var limitIdx int
+	var limit *Conds
 	for idx, cond := range conds {
 		if cond.Skip {
 			continue
 		}
-		if strings.Contains(cond.Key, "LIMIT") {
-			limitIdx = idx
+		if strings.HasPrefix(cond.Key, "LIMIT ") {
+			limit = &conds[idx]
 			continue
 		}
-		if len(cond.Values) > 0 && cond.Values[0] != "" {
-			query += fmt.Sprintf("%s %s ", cond.Key, op)
+		if cond.active() {
+			query += fmt.Sprintf("%s %s ", paren(cond.Key), op)
 			values = append(values, cond.Values...)
-		} else if !strings.Contains(cond.Key, "?") {
-			query += fmt.Sprintf("%s %s ", cond.Key, op)
 		}
 	}
 
@@ -79,9 +79,9 @@ func Build(conds []Conds, opr ...string) (string, []interface{}) {
 	} else {
 		query = query[:len(query)-4]
 	}
-	if limitIdx > 0 {
-		query += fmt.Sprintf(" %s", conds[limitIdx].Key)
-		values = append(values, conds[limitIdx].Values...)
+	if limit != nil {
+		query += fmt.Sprintf(" %s", limit.Key)
+		values = append(values, limit.Values...)
 	}
 
 	return query, values
@@ -93,7 +93,7 @@ func skipCond() Conds {
 
 func IntToBool(k string, i int) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s = ?", k),
+		Key:    fmt.Sprintf("%s = ?", column(k)),
 		Values: V[any]{i == 1},
 		Skip:   i == 0,
 	}
@@ -101,7 +101,7 @@ func IntToBool(k string, i int) Conds {
 
 func Like(k string, v string) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s LIKE ?", k),
+		Key:    fmt.Sprintf("%s LIKE ?", column(k)),
 		Values: V[any]{"%" + v + "%"},
 		Skip:   v == "",
 	}
@@ -109,7 +109,7 @@ func Like(k string, v string) Conds {
 
 func ILike(k string, v string) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s ILIKE ?", k),
+		Key:    fmt.Sprintf("%s ILIKE ?", column(k)),
 		Values: V[any]{"%" + v + "%"},
 		Skip:   v == "",
 	}
@@ -132,7 +132,7 @@ func ILikeMulti(fields []string, value string) Conds {
 	values := make([]interface{}, len(fields))
 
 	for i, field := range fields {
-		conditions[i] = fmt.Sprintf("%s ILIKE ?", field)
+		conditions[i] = fmt.Sprintf("%s ILIKE ?", column(field))
 		values[i] = "%" + value + "%"
 	}
 
@@ -163,7 +163,7 @@ func ILikeMultiValues(fields []string, values []string) Conds {
 	for _, field := range fields {
 		for _, value := range values {
 			if value != "" {
-				conditions = append(conditions, fmt.Sprintf("%s ILIKE ?", field))
+				conditions = append(conditions, fmt.Sprintf("%s ILIKE ?", column(field)))
 				queryValues = append(queryValues, "%"+value+"%")
 			}
 		}
@@ -186,7 +186,7 @@ func ILikeMultiValues(fields []string, values []string) Conds {
 
 func IntGreaterOrEqual(k string, i int) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s >= ?", k),
+		Key:    fmt.Sprintf("%s >= ?", column(k)),
 		Values: V[any]{i},
 		Skip:   i == 0,
 	}
@@ -207,16 +207,19 @@ func ReplacePlaceholder(q string, start ...int) string {
 
 func Geo(k string, long, lat, radius float64) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("ST_DWithin(%s, ST_Point(?, ?)::geography, ?)", k),
+		Key:    fmt.Sprintf("ST_DWithin(%s, ST_Point(?, ?)::geography, ?)", column(k)),
 		Values: V[any]{long, lat, radius},
 		Skip:   long == 0 || lat == 0 || radius == 0,
 	}
 }
 
-func OrderGeo(k string, long, lat float64) string {
-	return fmt.Sprintf("ST_Distance( %s, ST_Point(%f, %f))", k, long, lat)
+// OrderGeo orders by the distance of k to a point, the coordinates are bound
+// Use it with db.Order(clause.OrderBy{Expression: clause.Expr{SQL: sql, Vars: vars}}) or SortGeo
+func OrderGeo(k string, long, lat float64) (string, []any) {
+	return fmt.Sprintf("ST_Distance(%s, ST_Point(?, ?))", column(k)), []any{long, lat}
 }
 
+// Text creates a full text search condition, k is written as it is so it can be an expression
 func Text(k string, v string) Conds {
 	return Conds{
 		Key: fmt.Sprintf("to_tsvector('simple', %s) @@ to_tsquery('simple', ?)", k),
@@ -253,41 +256,24 @@ func TextPrefix(k string, v string) Conds {
 
 func Eq(k string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s = ?", k),
+		Key:    fmt.Sprintf("%s = ?", column(k)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil, skip...),
 	}
 }
 
+// StrArr checks if a PostgreSQL array field shares a value with v, a []string
+// Example: StrArr("tags", []string{"a", "b"}) => "tags && ARRAY[?,?]::text[]"
 func StrArr(k string, v interface{}, skip ...bool) Conds {
-	// Convert the slice to PostgreSQL array format
-	if strSlice, ok := v.([]string); ok && len(strSlice) > 0 {
-		// Create PostgreSQL array literal format: ARRAY['value1','value2']
-		quotedValues := make([]string, len(strSlice))
-		for i, s := range strSlice {
-			// Escape single quotes in values
-			quotedValues[i] = "'" + strings.ReplaceAll(s, "'", "''") + "'"
-		}
-		arrayLiteral := "ARRAY[" + strings.Join(quotedValues, ",") + "]::text[]"
-
-		return Conds{
-			Key:    fmt.Sprintf("%s && %s", k, arrayLiteral),
-			Values: V[any]{},
-			Skip:   getOption(false, skip...),
-		}
-	}
-
-	return Conds{
-		Key:    "",
-		Values: V[any]{},
-		Skip:   true,
-	}
+	strSlice, _ := v.([]string)
+	return arrayOverlap(k, "", strSlice, skip...)
 }
 
 func IsEmptyUUID(id uuid.UUID) bool {
 	return id == uuid.Nil || id.String() == ""
 }
 
+// Custom creates a condition of the SQL k, which is written as it is, never build k from request input
 func Custom[T any](k string, v T, skip ...bool) Conds {
 	return Conds{
 		Key:    k,
@@ -298,7 +284,7 @@ func Custom[T any](k string, v T, skip ...bool) Conds {
 
 func NotEq(k string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s != ?", k),
+		Key:    fmt.Sprintf("%s != ?", column(k)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil, skip...),
 	}
@@ -307,7 +293,7 @@ func NotEq(k string, v interface{}, skip ...bool) Conds {
 func In[T any](k string, v []T, skip ...bool) Conds {
 	if len(v) == 0 {
 		return Conds{
-			Key:    fmt.Sprintf("%s IS NULL", k),
+			Key:    fmt.Sprintf("%s IS NULL", column(k)),
 			Values: V[any]{},
 			Skip:   getOption(true, skip...),
 		}
@@ -318,7 +304,7 @@ func In[T any](k string, v []T, skip ...bool) Conds {
 		values[i] = val
 	}
 	return Conds{
-		Key:    fmt.Sprintf("%s IN (%s)", k, placeholders),
+		Key:    fmt.Sprintf("%s IN (%s)", column(k), placeholders),
 		Values: values,
 		Skip:   getOption(false, skip...),
 	}
@@ -327,30 +313,43 @@ func In[T any](k string, v []T, skip ...bool) Conds {
 func InSeperated(k string, v []string, skip ...bool) Conds {
 	if len(v) == 0 || v[0] == "" {
 		return Conds{
-			Key:    fmt.Sprintf("%s IS NULL", k),
+			Key:    fmt.Sprintf("%s IS NULL", column(k)),
 			Values: V[any]{},
 			Skip:   getOption(true, skip...),
 		}
 	}
-	placeholders := strings.Repeat("?,", len(v)-1) + "?"
+	return In(k, v, skip...)
+}
+
+func Min(k string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s IN (%s)", k, placeholders),
+		Key:    fmt.Sprintf("%s >= ?", column(k)),
 		Values: V[any]{v},
-		Skip:   getOption(false, skip...),
+		Skip:   getOption(v == nil, skip...),
 	}
 }
 
-func Min(k string, v interface{}, skip ...bool) Conds {
+func Max(k string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s >= ?", k),
+		Key:    fmt.Sprintf("%s <= ?", column(k)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil, skip...),
 	}
 }
 
-func Max(k string, v interface{}, skip ...bool) Conds {
+// Gt is the exclusive form of Min
+func Gt(k string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s <= ?", k),
+		Key:    fmt.Sprintf("%s > ?", column(k)),
+		Values: V[any]{v},
+		Skip:   getOption(v == nil, skip...),
+	}
+}
+
+// Lt is the exclusive form of Max
+func Lt(k string, v interface{}, skip ...bool) Conds {
+	return Conds{
+		Key:    fmt.Sprintf("%s < ?", column(k)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil, skip...),
 	}
@@ -358,7 +357,7 @@ func Max(k string, v interface{}, skip ...bool) Conds {
 
 func NotNull(k string, skip bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s IS NOT NULL", k),
+		Key:    fmt.Sprintf("%s IS NOT NULL", column(k)),
 		Values: V[any]{},
 		Skip:   skip,
 	}
@@ -373,9 +372,13 @@ func NotIn[T any](k string, v []T, skip ...bool) Conds {
 		}
 	}
 	placeholders := strings.Repeat("?,", len(v)-1) + "?"
+	values := make([]any, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
 	return Conds{
-		Key:    fmt.Sprintf("%s NOT IN (%s)", k, placeholders),
-		Values: V[any]{v},
+		Key:    fmt.Sprintf("%s NOT IN (%s)", column(k), placeholders),
+		Values: values,
 		Skip:   getOption(false, skip...),
 	}
 }
@@ -388,19 +391,14 @@ func NotInSeperated(k string, v []string, skip ...bool) Conds {
 			Skip:   getOption(true, skip...),
 		}
 	}
-	placeholders := strings.Repeat("?,", len(v)-1) + "?"
-	return Conds{
-		Key:    fmt.Sprintf("%s NOT IN (%s)", k, placeholders),
-		Values: V[any]{v},
-		Skip:   getOption(false, skip...),
-	}
+	return NotIn(k, v, skip...)
 }
 
 // ArrayContains checks if a single value is contained in a PostgreSQL array field
 // Example: "? = ANY(tags)" where tags is an array column
 func ArrayContains(k string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("? = ANY(%s)", k),
+		Key:    fmt.Sprintf("? = ANY(%s)", column(k)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil || v == "", skip...),
 	}
@@ -418,7 +416,7 @@ func ArrayEquals(k string, v []string, skip ...bool) Conds {
 		values[i] = val
 	}
 	return Conds{
-		Key:    fmt.Sprintf("%s && ARRAY[%s]::text[]", k, varlen),
+		Key:    fmt.Sprintf("%s && ARRAY[%s]::text[]", column(k), varlen),
 		Values: values,
 		Skip:   getOption(len(v) == 0, skip...),
 	}
@@ -428,7 +426,7 @@ func ArrayEquals(k string, v []string, skip ...bool) Conds {
 // Example: JsonbField("address", "city", "New York") => "address->>'city' = ?"
 func JsonbField(k string, field string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s->>'%s' = ?", k, field),
+		Key:    fmt.Sprintf("%s = ?", jsonPath(k, field)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil || v == "", skip...),
 	}
@@ -438,7 +436,7 @@ func JsonbField(k string, field string, v interface{}, skip ...bool) Conds {
 // Example: JsonbFieldNullSafe("address", "city", "New York") => "(address IS NOT NULL AND address->>'city' = ?)"
 func JsonbFieldNullSafe(k string, field string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("(%s IS NOT NULL AND %s->>'%s' = ?)", k, k, field),
+		Key:    fmt.Sprintf("(%s IS NOT NULL AND %s = ?)", column(k), jsonPath(k, field)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil || v == "", skip...),
 	}
@@ -447,7 +445,7 @@ func JsonbFieldNullSafe(k string, field string, v interface{}, skip ...bool) Con
 // JsonbFieldILike creates an ILIKE condition for JSONB field
 func JsonbFieldILike(k string, field string, v string, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("%s->>'%s' ILIKE ?", k, field),
+		Key:    fmt.Sprintf("%s ILIKE ?", jsonPath(k, field)),
 		Values: V[any]{"%" + v + "%"},
 		Skip:   getOption(v == "", skip...),
 	}
@@ -456,7 +454,7 @@ func JsonbFieldILike(k string, field string, v string, skip ...bool) Conds {
 // JsonbFieldILikeNullSafe creates a null-safe ILIKE condition for JSONB field
 func JsonbFieldILikeNullSafe(k string, field string, v string, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("(%s IS NOT NULL AND %s->>'%s' ILIKE ?)", k, k, field),
+		Key:    fmt.Sprintf("(%s IS NOT NULL AND %s ILIKE ?)", column(k), jsonPath(k, field)),
 		Values: V[any]{"%" + v + "%"},
 		Skip:   getOption(v == "", skip...),
 	}
@@ -468,26 +466,8 @@ func JsonbNestedField(k string, path []string, v interface{}, skip ...bool) Cond
 	if len(path) == 0 {
 		return skipCond()
 	}
-
-	var pathBuilder strings.Builder
-	pathBuilder.WriteString(k)
-
-	for i, segment := range path {
-		if i == len(path)-1 {
-			// Last element uses ->> for text extraction
-			pathBuilder.WriteString("->>'")
-			pathBuilder.WriteString(segment)
-			pathBuilder.WriteString("'")
-		} else {
-			// Intermediate elements use -> for JSON navigation
-			pathBuilder.WriteString("->'")
-			pathBuilder.WriteString(segment)
-			pathBuilder.WriteString("'")
-		}
-	}
-
 	return Conds{
-		Key:    fmt.Sprintf("%s = ?", pathBuilder.String()),
+		Key:    fmt.Sprintf("%s = ?", jsonPath(k, path...)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil || v == "", skip...),
 	}
@@ -498,26 +478,8 @@ func JsonbNestedFieldILike(k string, path []string, v string, skip ...bool) Cond
 	if len(path) == 0 {
 		return skipCond()
 	}
-
-	var pathBuilder strings.Builder
-	pathBuilder.WriteString(k)
-
-	for i, segment := range path {
-		if i == len(path)-1 {
-			// Last element uses ->> for text extraction
-			pathBuilder.WriteString("->>'")
-			pathBuilder.WriteString(segment)
-			pathBuilder.WriteString("'")
-		} else {
-			// Intermediate elements use -> for JSON navigation
-			pathBuilder.WriteString("->'")
-			pathBuilder.WriteString(segment)
-			pathBuilder.WriteString("'")
-		}
-	}
-
 	return Conds{
-		Key:    fmt.Sprintf("%s ILIKE ?", pathBuilder.String()),
+		Key:    fmt.Sprintf("%s ILIKE ?", jsonPath(k, path...)),
 		Values: V[any]{"%" + v + "%"},
 		Skip:   getOption(v == "", skip...),
 	}
@@ -538,25 +500,7 @@ func JsonbMultiFieldsILike(k string, paths [][]string, v string, skip ...bool) C
 		if len(path) == 0 {
 			continue
 		}
-
-		var pathBuilder strings.Builder
-		pathBuilder.WriteString(k)
-
-		for i, segment := range path {
-			if i == len(path)-1 {
-				// Last element uses ->> for text extraction
-				pathBuilder.WriteString("->>'")
-				pathBuilder.WriteString(segment)
-				pathBuilder.WriteString("'")
-			} else {
-				// Intermediate elements use -> for JSON navigation
-				pathBuilder.WriteString("->'")
-				pathBuilder.WriteString(segment)
-				pathBuilder.WriteString("'")
-			}
-		}
-
-		conditions = append(conditions, fmt.Sprintf("%s ILIKE ?", pathBuilder.String()))
+		conditions = append(conditions, fmt.Sprintf("%s ILIKE ?", jsonPath(k, path...)))
 		values = append(values, "%"+v+"%")
 	}
 
@@ -572,34 +516,35 @@ func JsonbMultiFieldsILike(k string, paths [][]string, v string, skip ...bool) C
 }
 
 // JsonbArrayOverlap creates a condition to check if JSONB array contains any of the given values
-// Example: JsonbArrayOverlap("audience", "interest", []string{"hiking", "camping"}) => "audience->'interest' ?| ARRAY['hiking','camping']"
+// Example: JsonbArrayOverlap("audience", "interest", []string{"hiking", "camping"}) => "jsonb_exists_any(audience->'interest', ARRAY[?,?]::text[])"
+// jsonb_exists_any is the function of the ?| operator, which GORM would read as a placeholder
 func JsonbArrayOverlap(k string, field string, values []string, skip ...bool) Conds {
-	if len(values) == 0 {
+	placeholders, vals := arrayValues("", values)
+	if len(vals) == 0 {
 		return skipCond()
 	}
-
-	quoted := make([]string, len(values))
-	for i, v := range values {
-		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
-	}
-
 	return Conds{
-		Key:    fmt.Sprintf("%s->'%s' ?| ARRAY[%s]::text[]", k, field, strings.Join(quoted, ",")),
-		Values: V[any]{},
+		Key:    fmt.Sprintf("jsonb_exists_any(%s->%s, ARRAY[%s]::text[])", column(k), QuoteLiteral(field), placeholders),
+		Values: vals,
 		Skip:   getOption(false, skip...),
 	}
 }
 
 // JsonbAgeRange creates a condition to check if a value falls within a JSONB age range array
-// Example: JsonbAgeRange("audience", 25) => "(audience->'age_range'->0)::int <= 25 AND (audience->'age_range'->1)::int >= 25"
+// Example: JsonbAgeRange("audience", 25) => "((audience->'age_range'->>0)::int <= ? AND (audience->'age_range'->>1)::int >= ?)"
 func JsonbAgeRange(k string, age int, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("(%s->'age_range'->0)::int <= %d AND (%s->'age_range'->1)::int >= %d", k, age, k, age),
-		Values: V[any]{},
+		Key:    ageRange(k),
+		Values: V[any]{age, age},
 		Skip:   getOption(age == 0, skip...),
 	}
 }
 
+func ageRange(k string) string {
+	k = column(k)
+	return fmt.Sprintf("((%s->'age_range'->>0)::int <= ? AND (%s->'age_range'->>1)::int >= ?)", k, k)
+}
+
 // PersonalizationScore creates a scoring expression for personalization
 type PersonalizationScoreField struct {
 	Type   string      // "interests", "badges", "gender", "age"
@@ -607,63 +552,68 @@ type PersonalizationScoreField struct {
 	Points int         // Points to award if matched
 }
 
-// BuildPersonalizationScore creates a complex personalization scoring expression
-func BuildPersonalizationScore(jsonbField string, fields []PersonalizationScoreField) string {
+// BuildPersonalizationScore creates a complex personalization scoring expression and its bound values
+// Use it with clause.Expr{SQL: sql, Vars: vars}, e.g. in db.Select or db.Order
+func BuildPersonalizationScore(jsonbField string, fields []PersonalizationScoreField) (string, []any) {
 	if len(fields) == 0 {
-		return "0"
+		return "0", nil
 	}
 
-	var expressions []string
+	var (
+		expressions []string
+		vars        []any
+	)
 
 	for _, field := range fields {
 		switch field.Type {
 		case "interest", "badges":
 			if values, ok := field.Value.([]string); ok && len(values) > 0 {
-				quoted := make([]string, len(values))
-				for i, v := range values {
-					quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+				placeholders, vals := arrayValues("", values)
+				if len(vals) == 0 {
+					continue
 				}
-				expr := fmt.Sprintf("CASE WHEN %s->'%s' ?| ARRAY[%s]::text[] THEN %d ELSE 0 END",
-					jsonbField, field.Type, strings.Join(quoted, ","), field.Points)
+				expr := fmt.Sprintf("CASE WHEN jsonb_exists_any(%s->%s, ARRAY[%s]::text[]) THEN ? ELSE 0 END",
+					column(jsonbField), QuoteLiteral(field.Type), placeholders)
 				expressions = append(expressions, expr)
+				vars = append(append(vars, vals...), field.Points)
 			}
 		case "gender":
 			if value, ok := field.Value.(string); ok && value != "" {
-				expr := fmt.Sprintf("CASE WHEN %s->>'gender' = '%s' THEN %d ELSE 0 END",
-					jsonbField, strings.ReplaceAll(value, "'", "''"), field.Points)
+				expr := fmt.Sprintf("CASE WHEN %s = ? THEN ? ELSE 0 END", jsonPath(jsonbField, "gender"))
 				expressions = append(expressions, expr)
+				vars = append(vars, value, field.Points)
 			}
 		case "age":
 			if age, ok := field.Value.(int); ok && age > 0 {
-				expr := fmt.Sprintf("CASE WHEN (%s->'age_range'->0)::int <= %d AND (%s->'age_range'->1)::int >= %d THEN %d ELSE 0 END",
-					jsonbField, age, jsonbField, age, field.Points)
+				expr := fmt.Sprintf("CASE WHEN %s THEN ? ELSE 0 END", ageRange(jsonbField))
 				expressions = append(expressions, expr)
+				vars = append(vars, age, age, field.Points)
 			}
 		}
 	}
 
 	if len(expressions) == 0 {
-		return "0"
+		return "0", nil
 	}
 
-	return "(" + strings.Join(expressions, " + ") + ")"
+	return "(" + strings.Join(expressions, " + ") + ")", vars
 }
 
 // JsonbNumericMin creates a condition for JSONB numeric field minimum value
-// Example: JsonbNumericMin("review", "average_point", 4.5) => "(review->>'average_point')::float >= 4.5"
+// Example: JsonbNumericMin("review", "average_point", 4.5) => "(review->>'average_point')::float >= ?"
 func JsonbNumericMin(k string, field string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("(%s->>'%s')::float >= ?", k, field),
+		Key:    fmt.Sprintf("(%s)::float >= ?", jsonPath(k, field)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil, skip...),
 	}
 }
 
 // JsonbNumericMax creates a condition for JSONB numeric field maximum value
-// Example: JsonbNumericMax("review", "average_point", 4.5) => "(review->>'average_point')::float <= 4.5"
+// Example: JsonbNumericMax("review", "average_point", 4.5) => "(review->>'average_point')::float <= ?"
 func JsonbNumericMax(k string, field string, v interface{}, skip ...bool) Conds {
 	return Conds{
-		Key:    fmt.Sprintf("(%s->>'%s')::float <= ?", k, field),
+		Key:    fmt.Sprintf("(%s)::float <= ?", jsonPath(k, field)),
 		Values: V[any]{v},
 		Skip:   getOption(v == nil, skip...),
 	}
@@ -673,23 +623,35 @@ func JsonbNumericMax(k string, field string, v interface{}, skip ...bool) Conds
 // This is useful for tags where database stores '#tag' but search terms come without '#'
 // Example: StrArrWithPrefix("tags", []string{"sapanca", "bolu"}, "#") will match against ['#sapanca', '#bolu']
 func StrArrWithPrefix(k string, v interface{}, prefix string, skip ...bool) Conds {
-	// Convert the slice to PostgreSQL array format with prefix
-	if strSlice, ok := v.([]string); ok && len(strSlice) > 0 {
-		// Create PostgreSQL array literal format with prefix: ARRAY['#value1','#value2']
-		quotedValues := make([]string, len(strSlice))
-		for i, s := range strSlice {
-			// Add prefix and escape single quotes in values
-			quotedValues[i] = "'" + prefix + strings.ReplaceAll(s, "'", "''") + "'"
-		}
-		arrayLiteral := "ARRAY[" + strings.Join(quotedValues, ",") + "]::text[]"
+	strSlice, _ := v.([]string)
+	return arrayOverlap(k, prefix, strSlice, skip...)
+}
 
-		return Conds{
-			Key:    fmt.Sprintf("%s && %s", k, arrayLiteral),
-			Values: V[any]{},
-			Skip:   getOption(false, skip...),
+// arrayOverlap checks if the array k shares a value with the prefixed values, empty values are left out
+func arrayOverlap(k, prefix string, values []string, skip ...bool) Conds {
+	placeholders, vals := arrayValues(prefix, values)
+	if len(vals) == 0 {
+		return skipCond()
+	}
+	return Conds{
+		Key:    fmt.Sprintf("%s && ARRAY[%s]::text[]", column(k), placeholders),
+		Values: vals,
+		Skip:   getOption(false, skip...),
+	}
+}
+
+// arrayValues returns the placeholders and values of the prefixed values that aren't empty
+func arrayValues(prefix string, values []string) (string, []any) {
+	vals := make([]any, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			vals = append(vals, prefix+v)
 		}
 	}
-	return skipCond()
+	if len(vals) == 0 {
+		return "", nil
+	}
+	return strings.Repeat("?,", len(vals)-1) + "?", vals
 }
 
 func getOption[V any](v V, opts ...V) V {
@@ -89,6 +89,16 @@ func TestBuild(t *testing.T) {
 	if query != "name = ? AND age = ? LIMIT ? OFFSET ?" || fmt.Sprintf("%v", values) != "[John 30 10 0]" {
 		t.Errorf("Build() with LIMIT condition returned incorrect query or values")
 	}
+
+	// Test with a key joining conditions
+	conds = []Conds{
+		ILikeMulti([]string{"title", "description"}, "milk"),
+		Eq("status", "pending"),
+	}
+	query, values = Build(conds)
+	if query != "(title ILIKE ? OR description ILIKE ?) AND status = ?" || fmt.Sprintf("%v", values) != "[%milk% %milk% pending]" {
+		t.Errorf("Build() with a joined key = %q %v", query, values)
+	}
 }
 func TestReplacePlaceholder(t *testing.T) {
 	// Test with single question mark
@@ -2,10 +2,13 @@ package query
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/salihguru/idiogo/pkg/ptr"
 	"github.com/salihguru/idiogo/pkg/xopt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func GetOrder(isAsc bool) string {
@@ -15,58 +18,102 @@ func GetOrder(isAsc bool) string {
 	return SortDesc
 }
 
+// Placement of the null values of a sort key
+const (
+	NullsFirst = "NULLS FIRST"
+	NullsLast  = "NULLS LAST"
+)
+
 type SortCond struct {
+	// Key is the column, Expr quotes what ValidIdent rejects like the builders do
+	// A key with Values is an expression written as it is, e.g. the one of SortGeo
 	Key       string
 	Skip      bool
 	Direction string
 	IsDefault bool
+
+	// Nulls is NullsFirst or NullsLast, the database default when empty
+	Nulls string
+
+	// Values are bound to the placeholders of Key, e.g. the point of SortGeo
+	Values []any
 }
 
-func sortExpr(key string, direction *string) string {
-	if direction == nil || *direction == "" {
+// sortExpr orders by key, any direction other than DESC is ASC so it can't extend the clause
+func sortExpr(key string, dir *string) string {
+	if dir == nil || *dir == "" {
 		return key
 	}
-	return fmt.Sprintf("%s %s", key, *direction)
+	return fmt.Sprintf("%s %s", key, direction(*dir))
 }
 
 func (s SortCond) Expr() string {
-	return sortExpr(s.Key, ptr.String(s.Direction))
+	expr := sortExpr(s.column(), ptr.String(s.Direction))
+	if s.Nulls == NullsFirst || s.Nulls == NullsLast {
+		expr += " " + s.Nulls
+	}
+	return expr
+}
+
+// column is the key as it is written in SQL
+func (s SortCond) column() string {
+	if len(s.Values) > 0 {
+		return s.Key
+	}
+	return column(s.Key)
 }
 
 func SortDirect(key string, isAsc ...bool) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		return db.Order(sortExpr(key, ptr.String(GetOrder(xopt.Get(false, isAsc...)))))
+		return db.Order(sortExpr(column(key), ptr.String(GetOrder(xopt.Get(false, isAsc...)))))
 	}
 }
 
+// Sort orders by every cond not skipped, in their order
+// When every cond is skipped it orders by the ones marked IsDefault instead
 func Sort(conds []SortCond) func(db *gorm.DB) *gorm.DB {
-	return func(db *gorm.DB) *gorm.DB {
-		if len(conds) == 0 {
-			return db
-		}
-		isApplied := false
-		var defaultSort string
+	if !slices.ContainsFunc(conds, func(cond SortCond) bool { return !cond.Skip }) {
+		var defaults []SortCond
 		for _, cond := range conds {
 			if cond.IsDefault {
-				defaultSort = cond.Expr()
+				cond.Skip = false
+				defaults = append(defaults, cond)
 			}
-			if cond.Skip {
-				continue
+		}
+		conds = defaults
+	}
+	return OrderBy(conds)
+}
+
+// OrderBy orders by every cond not skipped, like Sort without falling back to the default conds
+// Conds with values are applied as one expression, which replaces the orders applied before it
+func OrderBy(conds []SortCond) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		var (
+			exprs []string
+			vars  []any
+		)
+		for _, cond := range conds {
+			if !cond.Skip {
+				exprs = append(exprs, cond.Expr())
+				vars = append(vars, cond.Values...)
 			}
-			db = db.Order(cond.Expr())
-			isApplied = true
-			break
 		}
-		if !isApplied && defaultSort != "" {
-			db = db.Order(defaultSort)
+		if len(vars) > 0 {
+			return db.Order(clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(exprs, ", "), Vars: vars}})
+		}
+		for _, expr := range exprs {
+			db = db.Order(expr)
 		}
 		return db
 	}
 }
 
 func SortGeo(k string, long *float64, lat *float64, skip bool, isDefault ...bool) SortCond {
+	key, values := OrderGeo(k, ptr.Float64Ref(long), ptr.Float64Ref(lat))
 	return SortCond{
-		Key:       OrderGeo("start_point", ptr.Float64Ref(long), ptr.Float64Ref(lat)),
+		Key:       key,
+		Values:    values,
 		Skip:      skip || long == nil || lat == nil,
 		IsDefault: getOption(false, isDefault...),
 	}
@@ -80,3 +127,154 @@ func SortBasic(k, dir string, skip bool, isDefault ...bool) SortCond {
 		IsDefault: getOption(false, isDefault...),
 	}
 }
+
+// TagSortField is the validation tag of unknown sort fields
+const TagSortField = "sort_field"
+
+// SortReq is embedded by list requests accepting a sort parameter, e.g. sort=-created_at,title
+// A leading - sorts a field in descending order
+type SortReq struct {
+	Sort string `query:"sort" validate:"omitempty,max=256"`
+}
+
+func (r SortReq) QuerySort() string {
+	return r.Sort
+}
+
+// Sorter is implemented by requests with a sort parameter, ParamChecker checks it against SortSpec
+type Sorter interface {
+	QuerySort() string
+	SortSpec() *SortSpec
+}
+
+// SortField allows sorting by a field, see SortOn
+type SortField struct {
+	name   string
+	column string
+	nulls  string
+}
+
+// SortOn allows sorting by the column name
+func SortOn(name string) SortField {
+	return SortField{name: name, column: name}
+}
+
+// Column sorts by column instead of the column named like the field
+func (f SortField) Column(column string) SortField {
+	f.column = column
+	return f
+}
+
+// Nulls places the null values of the field, NullsFirst or NullsLast
+func (f SortField) Nulls(nulls string) SortField {
+	f.nulls = nulls
+	return f
+}
+
+// SortSpec is the allowlist of the sort fields of an entity
+type SortSpec struct {
+	fields     map[string]SortField
+	names      string
+	def        string
+	tiebreaker string
+}
+
+// NewSortSpec builds the allowlist of fields, def is the sort of requests without one, e.g. -created_at
+// It panics on invalid columns and when def uses a field that is not allowed
+func NewSortSpec(def string, fields ...SortField) *SortSpec {
+	s := &SortSpec{fields: make(map[string]SortField, len(fields)), def: def, tiebreaker: KeyID}
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !ValidIdent(f.column) {
+			panic(fmt.Sprintf("query: sort field %s has an invalid column %q", f.name, f.column))
+		}
+		if f.nulls != "" && f.nulls != NullsFirst && f.nulls != NullsLast {
+			panic(fmt.Sprintf("query: sort field %s has an unknown nulls placement %q", f.name, f.nulls))
+		}
+		s.fields[f.name] = f
+		names = append(names, f.name)
+	}
+	slices.Sort(names)
+	s.names = strings.Join(names, " ")
+	if _, errs := s.conds(def); len(errs) > 0 {
+		panic(fmt.Sprintf("query: default sort: %v", errs))
+	}
+	return s
+}
+
+// Tiebreaker returns the spec ending the orders with column instead of id
+// Qualify it when the query joins tables with their own id, e.g. todos.id
+// It panics on invalid columns
+func (s *SortSpec) Tiebreaker(column string) *SortSpec {
+	if !ValidIdent(column) {
+		panic(fmt.Sprintf("query: sort tiebreaker has an invalid column %q", column))
+	}
+	spec := *s
+	spec.tiebreaker = column
+	return &spec
+}
+
+// Fields returns the names of the sortable fields
+func (s *SortSpec) Fields() []string {
+	return strings.Fields(s.names)
+}
+
+// Check returns the sort fields the spec rejects
+func (s *SortSpec) Check(sort string) ParamErrors {
+	_, errs := s.conds(sort)
+	return errs
+}
+
+// Conds builds the order of sort, the default one when empty, ending with the tiebreaker so the order is stable
+// The columns come from the spec, so clients can't change the query
+func (s *SortSpec) Conds(sort string) ([]SortCond, error) {
+	conds, errs := s.conds(sort)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return conds, nil
+}
+
+func (s *SortSpec) conds(sort string) ([]SortCond, ParamErrors) {
+	if strings.TrimSpace(sort) == "" {
+		sort = s.def
+	}
+	var (
+		conds []SortCond
+		errs  ParamErrors
+		seen  []string
+	)
+	for _, item := range strings.Split(sort, ",") {
+		item = strings.TrimSpace(item)
+		dir := SortAsc
+		switch {
+		case strings.HasPrefix(item, "-"):
+			dir, item = SortDesc, item[1:]
+		case strings.HasPrefix(item, "+"):
+			item = item[1:]
+		}
+		if item == "" || slices.Contains(seen, item) {
+			continue
+		}
+		seen = append(seen, item)
+		f, ok := s.fields[item]
+		if !ok {
+			errs = append(errs, &ParamError{Field: "sort", Value: item, Tag: TagSortField, Param: s.names})
+			continue
+		}
+		conds = append(conds, SortCond{Key: f.column, Direction: dir, Nulls: f.nulls})
+	}
+	return withTiebreaker(conds, s.tiebreaker), errs
+}
+
+// withTiebreaker ends conds with key, in the direction of the last cond, unless they already sort by its column
+func withTiebreaker(conds []SortCond, key string) []SortCond {
+	dir := SortAsc
+	for _, cond := range conds {
+		if Column(cond.Key) == Column(key) {
+			return conds
+		}
+		dir = cond.Direction
+	}
+	return append(conds, SortCond{Key: key, Direction: dir})
+}
@@ -0,0 +1,131 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testSortSpec = NewSortSpec("-created_at",
+	SortOn("title"),
+	SortOn("created_at"),
+	SortOn("due").Column("due_at").Nulls(NullsLast),
+)
+
+func TestSortSpecConds(t *testing.T) {
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{sort: "", want: "created_at DESC,id DESC"},
+		{sort: "title", want: "title ASC,id ASC"},
+		{sort: "-created_at, +title", want: "created_at DESC,title ASC,id ASC"},
+		{sort: "-due,title,-due", want: "due_at DESC NULLS LAST,title ASC,id ASC"},
+		{sort: ",,", want: "id ASC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			conds, err := testSortSpec.Conds(tt.sort)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := KeysetName(conds); got != tt.want {
+				t.Errorf("Conds(%q) = %s, want %s", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortSpecErrors(t *testing.T) {
+	_, err := testSortSpec.Conds("title,-password,id;drop table todos")
+	var errs ParamErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Conds() = %v, want 2 errors", err)
+	}
+	if errs[0].Tag != TagSortField || errs[0].Value != "password" || errs[0].Param != "created_at due title" {
+		t.Errorf("error = %+v, want password rejected with the sortable fields", errs[0])
+	}
+}
+
+func TestSortExprDirection(t *testing.T) {
+	if got := SortBasic("title", "asc; DROP TABLE todos", false).Expr(); got != "title ASC" {
+		t.Errorf("Expr() = %q, want the direction limited to ASC or DESC", got)
+	}
+}
+
+func TestSortExprColumn(t *testing.T) {
+	tests := []struct {
+		cond SortCond
+		want string
+	}{
+		{SortCond{Key: "todos.title", Direction: SortAsc}, "todos.title ASC"},
+		{SortCond{Key: "order", Direction: SortDesc}, `"order" DESC`},
+		{SortCond{Key: "title; DROP TABLE todos"}, `"title; DROP TABLE todos"`},
+		{SortGeo("location", new(float64), new(float64), false), "ST_Distance(location, ST_Point(?, ?))"},
+	}
+	for _, tt := range tests {
+		if got := tt.cond.Expr(); got != tt.want {
+			t.Errorf("Expr() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestKeysetNulls(t *testing.T) {
+	if _, err := Keyset([]SortCond{{Key: "due_at", Nulls: NullsLast}}); err == nil {
+		t.Error("Keyset() accepted a key with nulls")
+	}
+}
+
+func TestSortTiebreaker(t *testing.T) {
+	spec := testSortSpec.Tiebreaker("todos.id")
+	tests := []struct {
+		spec *SortSpec
+		sort string
+		want string
+	}{
+		{spec: spec, sort: "", want: "created_at DESC,todos.id DESC"},
+		{spec: spec, sort: "title", want: "title ASC,todos.id ASC"},
+		{spec: testSortSpec, sort: "title", want: "title ASC,id ASC"},
+	}
+	for _, tt := range tests {
+		conds, err := tt.spec.Conds(tt.sort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := KeysetName(conds); got != tt.want {
+			t.Errorf("Conds(%q) = %s, want %s", tt.sort, got, tt.want)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		conds []SortCond
+		want  string
+	}{
+		{"every key", []SortCond{
+			SortBasic("title", SortAsc, false),
+			SortBasic("status", SortAsc, true, true),
+			SortBasic("created_at", SortDesc, false),
+		}, "ORDER BY title ASC,created_at DESC"},
+		{"defaults when all skipped", []SortCond{
+			SortBasic("title", SortAsc, true),
+			SortBasic("created_at", SortDesc, true, true),
+			SortBasic("id", SortDesc, true, true),
+		}, "ORDER BY created_at DESC,id DESC"},
+		{"none", []SortCond{SortBasic("title", SortAsc, true)}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, _ := scope(Sort(tt.conds))
+			var got string
+			if i := strings.Index(sql, "ORDER BY"); i >= 0 {
+				got = sql[i:]
+			}
+			if got != tt.want {
+				t.Errorf("Sort() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,7 @@ package server
 
 import (
 	"context"
-	"log"
+	"fmt"
 )
 
 // Server is the interface that must be implemented by a server
@@ -22,11 +22,15 @@ type Listener interface {
 	Shutdown(context.Context) error
 }
 
-func Start(name string, srv Listener, cb func()) {
+// Start runs the listener in a new goroutine
+// cb is called with the listener error, wrapped with the server name, once Listen returns
+// It never exits the process so shutdown hooks can still run
+func Start(name string, srv Listener, cb func(err error)) {
 	go func() {
-		defer cb()
-		if err := srv.Listen(); err != nil {
-			log.Fatal(name, " server error: ", err)
+		err := srv.Listen()
+		if err != nil {
+			err = fmt.Errorf("%s server: %w", name, err)
 		}
+		cb(err)
 	}()
 }
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultShutdownTimeout bounds the shutdown of each listener when none is configured
+const DefaultShutdownTimeout = 10 * time.Second
+
+// RestartPolicy restarts a listener whose Listen returns an error
+// The delay starts at Backoff and doubles on every restart up to MaxBackoff
+type RestartPolicy struct {
+	// MaxRestarts is the number of restarts before the error becomes fatal, 0 means never restart
+	MaxRestarts int
+
+	// Backoff is the delay before the first restart
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between restarts, no cap when zero
+	MaxBackoff time.Duration
+}
+
+func (p RestartPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+// SupervisorConfig configures a Supervisor
+type SupervisorConfig struct {
+	// ShutdownTimeout bounds the shutdown of each listener
+	ShutdownTimeout time.Duration
+}
+
+type entry struct {
+	name   string
+	srv    Listener
+	policy RestartPolicy
+}
+
+// Supervisor runs many named listeners together
+// The first fatal listener error stops every other listener
+type Supervisor struct {
+	cnf     SupervisorConfig
+	entries []entry
+}
+
+// NewSupervisor creates an empty supervisor
+func NewSupervisor(cnf SupervisorConfig) *Supervisor {
+	if cnf.ShutdownTimeout <= 0 {
+		cnf.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	return &Supervisor{cnf: cnf}
+}
+
+// Add registers a named listener with an optional restart policy
+// It must be called before Run
+func (s *Supervisor) Add(name string, srv Listener, policy ...RestartPolicy) {
+	e := entry{name: name, srv: srv}
+	if len(policy) > 0 {
+		e.policy = policy[0]
+	}
+	s.entries = append(s.entries, e)
+}
+
+// Run starts every listener and blocks until ctx is done or a listener fails fatally
+// Then it shuts every listener down and returns the joined listener and shutdown errors
+func (s *Supervisor) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+		cancel()
+	}
+	for _, e := range s.entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.listen(ctx, e); err != nil {
+				fail(err)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	var shutdowns sync.WaitGroup
+	for _, e := range s.entries {
+		shutdowns.Add(1)
+		go func() {
+			defer shutdowns.Done()
+			sctx, scancel := context.WithTimeout(context.Background(), s.cnf.ShutdownTimeout)
+			defer scancel()
+			if err := e.srv.Shutdown(sctx); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s server shutdown: %w", e.name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	shutdowns.Wait()
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// listen runs the listener, restarting it according to its policy
+// It returns nil when the listener stops cleanly or ctx is done
+func (s *Supervisor) listen(ctx context.Context, e entry) error {
+	for attempt := 1; ; attempt++ {
+		err := e.srv.Listen()
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+		err = fmt.Errorf("%s server: %w", e.name, err)
+		if attempt > e.policy.MaxRestarts {
+			return err
+		}
+		delay := e.policy.delay(attempt)
+		slog.Warn("server failed, restarting", "server", e.name, "error", err, "delay", delay, "attempt", attempt, "max_restarts", e.policy.MaxRestarts)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeListener blocks in Listen until Shutdown, or fails with the queued errors first
+type fakeListener struct {
+	mu       sync.Mutex
+	fails    []error
+	listens  int
+	stop     chan struct{}
+	once     sync.Once
+	shutdown bool
+}
+
+func newFakeListener(fails ...error) *fakeListener {
+	return &fakeListener{fails: fails, stop: make(chan struct{})}
+}
+
+func (f *fakeListener) Listen() error {
+	f.mu.Lock()
+	f.listens++
+	if len(f.fails) > 0 {
+		err := f.fails[0]
+		f.fails = f.fails[1:]
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	<-f.stop
+	return nil
+}
+
+func (f *fakeListener) Shutdown(context.Context) error {
+	f.once.Do(func() {
+		f.mu.Lock()
+		f.shutdown = true
+		f.mu.Unlock()
+		close(f.stop)
+	})
+	return nil
+}
+
+func TestSupervisorStopsOnContext(t *testing.T) {
+	a, b := newFakeListener(), newFakeListener()
+	s := NewSupervisor(SupervisorConfig{})
+	s.Add("a", a)
+	s.Add("b", b)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := s.Run(ctx); err != nil {
+		t.Errorf("Run() error = %v, want nil", err)
+	}
+	if !a.shutdown || !b.shutdown {
+		t.Error("Run() did not shut down every listener")
+	}
+}
+
+func TestSupervisorFatalErrorStopsOthers(t *testing.T) {
+	boom := errors.New("address already in use")
+	a, b := newFakeListener(boom), newFakeListener()
+	s := NewSupervisor(SupervisorConfig{})
+	s.Add("a", a)
+	s.Add("b", b)
+
+	err := s.Run(context.Background())
+
+	if !errors.Is(err, boom) {
+		t.Errorf("Run() error = %v, want %v", err, boom)
+	}
+	if !b.shutdown {
+		t.Error("Run() did not shut down the healthy listener")
+	}
+}
+
+func TestSupervisorRestarts(t *testing.T) {
+	boom := errors.New("temporary")
+	a := newFakeListener(boom, boom)
+	s := NewSupervisor(SupervisorConfig{})
+	s.Add("a", a, RestartPolicy{MaxRestarts: 2, Backoff: time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := s.Run(ctx); err != nil {
+		t.Errorf("Run() error = %v, want nil", err)
+	}
+	if a.listens != 3 {
+		t.Errorf("Listen() called %d times, want 3", a.listens)
+	}
+}
+
+func TestSupervisorRestartLimit(t *testing.T) {
+	boom := errors.New("temporary")
+	a := newFakeListener(boom, boom, boom)
+	s := NewSupervisor(SupervisorConfig{})
+	s.Add("a", a, RestartPolicy{MaxRestarts: 1, Backoff: time.Millisecond})
+
+	if err := s.Run(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("Run() error = %v, want %v", err, boom)
+	}
+	if a.listens != 2 {
+		t.Errorf("Listen() called %d times, want 2", a.listens)
+	}
+}
+
+func TestRestartPolicyDelay(t *testing.T) {
+	p := RestartPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := p.delay(i + 1); got != w {
+			t.Errorf("delay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
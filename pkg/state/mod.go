@@ -12,4 +12,5 @@ const (
 	KeyUserRefresh  contextKeyType = "user_refresh"
 	KeyCurrency     contextKeyType = "currency"
 	KeyDevice       contextKeyType = "device"
+	KeyRequestID    contextKeyType = "request_id"
 )
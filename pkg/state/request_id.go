@@ -0,0 +1,16 @@
+package state
+
+import "context"
+
+// SetRequestID sets the request id in the context
+func SetRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, KeyRequestID, requestID)
+}
+
+// RequestID gets the request id from the context
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(KeyRequestID).(string); ok {
+		return id
+	}
+	return ""
+}
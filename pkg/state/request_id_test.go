@@ -0,0 +1,38 @@
+package state
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "RequestIDExists",
+			ctx:  SetRequestID(context.Background(), "req-123"),
+			want: "req-123",
+		},
+		{
+			name: "RequestIDDoesNotExist",
+			ctx:  context.Background(),
+			want: "",
+		},
+		{
+			name: "WrongTypeInContext",
+			ctx:  context.WithValue(context.Background(), KeyRequestID, 123),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequestID(tt.ctx); got != tt.want {
+				t.Errorf("RequestID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"context"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -18,9 +19,24 @@ type Srv struct {
 	validator *validator.Validate
 	uni       *ut.UniversalTranslator
 	i18n      *i18np.I18n
+	checkers  []ParamChecker
 }
 
-func New(i18n *i18np.I18n) *Srv {
+// ParamChecker checks the request parameters validate tags can't describe, e.g. filters against the fields a request allows
+// ValidateStruct lists the errors it returns as field errors, see ParamError
+type ParamChecker interface {
+	CheckParams(req interface{}) []error
+}
+
+// ParamError is a parameter a ParamChecker rejects
+// Its tag and param fill the validation_<tag> message like a validate tag, Error() is the message when there is none
+type ParamError interface {
+	error
+	ParamDetail() (field, value, tag, param string)
+}
+
+// New creates the validation service, the checkers run after the validate tags on every ValidateStruct
+func New(i18n *i18np.I18n, checkers ...ParamChecker) *Srv {
 	v := validator.New()
 	
 	// Register custom validators
@@ -30,8 +46,9 @@ func New(i18n *i18np.I18n) *Srv {
 	v.RegisterValidation("slug", validateSlug)
 	v.RegisterValidation("gender", validateGender)
 	v.RegisterValidation("phone", validatePhone)
+	v.RegisterValidation("infield", validateInField)
 	
-	return &Srv{validator: v, uni: ut.New(tr.New(), en.New()), i18n: i18n}
+	return &Srv{validator: v, uni: ut.New(tr.New(), en.New()), i18n: i18n, checkers: checkers}
 }
 
 // Custom validation functions
@@ -81,17 +98,43 @@ func validatePhone(fl validator.FieldLevel) bool {
 	return matched
 }
 
+// validateInField checks that the value is one of the elements of the sibling slice field named by the param
+// Example: Default string `validate:"infield=Locales"`
+func validateInField(fl validator.FieldLevel) bool {
+	parent := reflect.Indirect(fl.Parent())
+	if parent.Kind() != reflect.Struct {
+		return false
+	}
+	list := parent.FieldByName(fl.Param())
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return false
+	}
+	for i := 0; i < list.Len(); i++ {
+		if reflect.DeepEqual(list.Index(i).Interface(), fl.Field().Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Srv) translate(ctx context.Context, err validator.FieldError) string {
-	if s.i18n == nil {
+	return s.translateTag(ctx, err.Tag(), err.Field(), err.Value(), err.Param(), func() string {
 		return err.Translate(s.getTranslator(ctx))
+	})
+}
+
+// translateTag translates the validation_<tag> message, fallback gives the message when it is missing
+func (s *Srv) translateTag(ctx context.Context, tag, field string, value interface{}, param string, fallback func() string) string {
+	if s.i18n == nil {
+		return fallback()
 	}
-	msg := s.i18n.TranslateWithParams("validation_"+err.Tag(), map[string]interface{}{
-		"Value": err.Value(),
-		"Field": err.Field(),
-		"Param": err.Param(),
+	msg := s.i18n.TranslateWithParams("validation_"+tag, map[string]interface{}{
+		"Value": value,
+		"Field": field,
+		"Param": param,
 	}, state.LocaleStr(ctx))
-	if msg == "" || msg == "validation_"+err.Tag() {
-		msg = err.Translate(s.getTranslator(ctx))
+	if msg == "" || msg == "validation_"+tag {
+		msg = fallback()
 	}
 	return msg
 }
@@ -113,12 +156,30 @@ func (s *Srv) ValidateStruct(ctx context.Context, sc interface{}) error {
 			errs = append(errs, &element)
 		}
 	}
+	for _, c := range s.checkers {
+		for _, err := range c.CheckParams(sc) {
+			errs = append(errs, s.paramError(ctx, err))
+		}
+	}
 	if len(errs) > 0 {
 		return xrescode.ValidationFailed().SetData(errs)
 	}
 	return nil
 }
 
+func (s *Srv) paramError(ctx context.Context, err error) *ErrorResponse {
+	pe, ok := err.(ParamError)
+	if !ok {
+		return &ErrorResponse{Message: err.Error()}
+	}
+	field, value, tag, param := pe.ParamDetail()
+	return &ErrorResponse{
+		Field:   field,
+		Value:   value,
+		Message: s.translateTag(ctx, tag, field, value, param, pe.Error),
+	}
+}
+
 // ValidateMap validates the giveb struct.
 func (s *Srv) ValidateMap(ctx context.Context, m map[string]interface{}, rules map[string]interface{}) error {
 	var errs []*ErrorResponse
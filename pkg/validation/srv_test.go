@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/restayway/rescode"
+	"github.com/salihguru/idiogo/pkg/query"
 )
 
 // ... (existing validation functions: validateUUID, validateIban, etc.)
@@ -124,3 +125,51 @@ func TestValidateMap(t *testing.T) {
 		t.Errorf("ValidateMap() did not return a rescode.Error")
 	}
 }
+
+func TestValidateInField(t *testing.T) {
+	type locales struct {
+		Locales []string
+		Default string `validate:"infield=Locales"`
+	}
+	s := New(nil)
+
+	if err := s.ValidateStruct(context.Background(), locales{Locales: []string{"en", "tr"}, Default: "tr"}); err != nil {
+		t.Errorf("ValidateStruct() with value in field returned an error: %v", err)
+	}
+	if err := s.ValidateStruct(context.Background(), locales{Locales: []string{"en", "tr"}, Default: "de"}); err == nil {
+		t.Error("ValidateStruct() with value not in field did not return an error")
+	}
+}
+
+type filterStruct struct {
+	Name string `validate:"required"`
+	query.FilterReq
+}
+
+var _ ParamChecker = query.ParamChecker{}
+
+var testFilterSpec = query.NewFilterSpec(query.FilterOn[int]("age", query.OpGte))
+
+func (filterStruct) FilterSpec() *query.FilterSpec {
+	return testFilterSpec
+}
+
+func TestValidateStructFilter(t *testing.T) {
+	s := New(nil, query.ParamChecker{})
+
+	valid := filterStruct{Name: "john", FilterReq: query.FilterReq{Filter: query.Filter{"age": {"gte": "18"}}}}
+	if err := s.ValidateStruct(context.Background(), &valid); err != nil {
+		t.Errorf("ValidateStruct() with a valid filter returned an error: %v", err)
+	}
+
+	invalid := filterStruct{FilterReq: query.FilterReq{Filter: query.Filter{"age": {"gte": "old"}}}}
+	err := s.ValidateStruct(context.Background(), &invalid)
+	rc, ok := err.(*rescode.RC)
+	if !ok {
+		t.Fatalf("ValidateStruct() = %v, want a rescode.Error", err)
+	}
+	errs, _ := rc.Data.([]*ErrorResponse)
+	if len(errs) != 2 || errs[1].Field != "filter[age][gte]" || errs[1].Message == "" {
+		t.Errorf("ValidateStruct() errors = %v, want the struct and the filter errors", errs)
+	}
+}
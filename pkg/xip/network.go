@@ -0,0 +1,49 @@
+package xip
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Networks is a parsed list of IPs and CIDRs
+type Networks []*net.IPNet
+
+// ParseNetworks parses IPs and CIDRs like "127.0.0.1" or "10.0.0.0/8"
+func ParseNetworks(list []string) (Networks, error) {
+	nets := make(Networks, 0, len(list))
+	for _, item := range list {
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("xip: invalid ip %q", item)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("xip: invalid cidr %q: %w", item, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// Contains reports whether ip is in any of the networks
+func (n Networks) Contains(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range n {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package xlog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/salihguru/idiogo/pkg/xtrace"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type contextKey struct{}
+
+// Config selects the handler of a new logger
+type Config struct {
+	// Format is FormatJSON or FormatText, text is used when empty
+	Format string
+
+	// Level is the minimum level, use a *slog.LevelVar to change it at runtime
+	Level slog.Leveler
+
+	// Output defaults to os.Stderr
+	Output io.Writer
+}
+
+// New creates a logger writing in the configured format
+// Records logged with a context carrying a span get its trace_id and span_id
+func New(cnf Config) *slog.Logger {
+	out := cnf.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	opts := &slog.HandlerOptions{Level: cnf.Level}
+	if cnf.Format == FormatJSON {
+		return slog.New(traceHandler{slog.NewJSONHandler(out, opts)})
+	}
+	return slog.New(traceHandler{slog.NewTextHandler(out, opts)})
+}
+
+// traceHandler adds the ids of the context span to every record
+type traceHandler struct {
+	slog.Handler
+}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := xtrace.SpanFrom(ctx).Context(); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID.String()), slog.String("span_id", sc.SpanID.String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
+
+// ParseLevel parses debug, info, warn or error, unknown levels are info
+func ParseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// WithLogger returns a context carrying the logger
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// From returns the logger of the context or slog.Default
+// example: xlog.From(ctx).Info("todo created", "id", todo.ID)
+func From(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+// With returns a context whose logger carries the given attributes
+// example: ctx = xlog.With(ctx, "todo_id", id)
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, From(ctx).With(args...))
+}
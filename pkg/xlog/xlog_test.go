@@ -0,0 +1,87 @@
+package xlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/salihguru/idiogo/pkg/xtrace"
+)
+
+func TestFrom(t *testing.T) {
+	t.Run("falls back to default", func(t *testing.T) {
+		if From(context.Background()) != slog.Default() {
+			t.Error("From() without logger did not return slog.Default()")
+		}
+	})
+
+	t.Run("returns context logger with fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		ctx := WithLogger(context.Background(), New(Config{Format: FormatJSON, Output: &buf}))
+		ctx = With(ctx, "request_id", "abc")
+
+		From(ctx).Info("hello")
+
+		var line map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+			t.Fatalf("log line is not json: %v", err)
+		}
+		if line["request_id"] != "abc" || line["msg"] != "hello" {
+			t.Errorf("log line = %v, want request_id and msg", line)
+		}
+	})
+}
+
+func TestNewLevel(t *testing.T) {
+	var buf bytes.Buffer
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelWarn)
+	l := New(Config{Format: FormatText, Level: level, Output: &buf})
+
+	l.Info("hidden")
+	level.Set(ParseLevel("debug"))
+	l.Debug("visible")
+
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "visible") {
+		t.Errorf("log output = %q, want only the debug line", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+		"":      slog.LevelInfo,
+		"bogus": slog.LevelInfo,
+	}
+	for in, want := range tests {
+		if got := ParseLevel(in); got != want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+type nopExporter struct{}
+
+func (nopExporter) Export(xtrace.SpanData)         {}
+func (nopExporter) Shutdown(context.Context) error { return nil }
+
+func TestNewTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Format: FormatJSON, Output: &buf})
+	ctx, span := xtrace.New(xtrace.Config{SampleRate: 1, Exporter: nopExporter{}}).Start(context.Background(), "op")
+
+	l.InfoContext(ctx, "traced")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("log line is not json: %v", err)
+	}
+	if line["trace_id"] != span.Context().TraceID.String() || line["span_id"] != span.Context().SpanID.String() {
+		t.Errorf("log line = %v, want the span ids", line)
+	}
+}
@@ -2,10 +2,19 @@ package xrepo
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/restayway/stx"
+	"github.com/salihguru/idiogo/pkg/list"
+	"github.com/salihguru/idiogo/pkg/query"
+	"github.com/salihguru/idiogo/pkg/xrescode"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 type ScopeFunc = func(*gorm.DB) *gorm.DB
@@ -17,13 +26,12 @@ func WithContext(ctx context.Context, db *gorm.DB) *gorm.DB {
 	return db.WithContext(ctx)
 }
 
+// ViewByWhere returns the first matching entity
+// Errors are classified with xrescode.Classify, a missing entity is xrescode.NotFound
 func ViewByWhere[T any](ctx context.Context, db *gorm.DB, where string, args ...interface{}) (*T, error) {
 	var entity T
 	if err := WithContext(ctx, db).Where(where, args...).First(&entity).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
-		}
-		return nil, err
+		return nil, xrescode.Classify(err)
 	}
 	return &entity, nil
 }
@@ -35,14 +43,148 @@ func ViewByID[T any](ctx context.Context, db *gorm.DB, id uuid.UUID) (*T, error)
 func Find[T any](ctx context.Context, db *gorm.DB, scopes ...ScopeFunc) ([]T, error) {
 	var entities []T
 	if err := WithContext(ctx, db).Scopes(scopes...).Find(&entities).Error; err != nil {
-		return nil, err
+		return nil, xrescode.Classify(err)
 	}
 	return entities, nil
 }
 
+// FindPaged returns the page of pagi in the order of order and the number of entities matching filters
+// The filters are applied to the count and the page, the order only to the page since a count can't be ordered
+// The count and the page are read in one repeatable read transaction so they agree,
+// a transaction already in ctx is used as is
+func FindPaged[T any](ctx context.Context, db *gorm.DB, pagi list.PagiRequest, order ScopeFunc, filters ...ScopeFunc) (*list.Page[T], error) {
+	pagi.Default()
+	var (
+		entities []T
+		total    int64
+	)
+	read := func(tx *gorm.DB) error {
+		if err := tx.Model(&entities).Scopes(filters...).Count(&total).Error; err != nil {
+			return err
+		}
+		if total == 0 || pagi.Offset() >= int(total) {
+			return nil
+		}
+		page := tx.Scopes(filters...)
+		if order != nil {
+			page = page.Scopes(order)
+		}
+		return page.Scopes(list.Paginate(&pagi)).Find(&entities).Error
+	}
+	var err error
+	if txdb := stx.Current(ctx); txdb != nil {
+		err = read(txdb)
+	} else {
+		err = db.WithContext(ctx).Transaction(read, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	}
+	if err != nil {
+		return nil, xrescode.Classify(err)
+	}
+	return list.NewPage(entities, pagi, total), nil
+}
+
+// FindByCursor returns the page of entities matching scopes after req.Cursor in the order of sorts
+// The rows are found with a keyset condition on the sort keys instead of an offset, see query.Keyset and query.After,
+// so pages stay fast and stable while rows are added or removed
+// The sort keys must be columns of T and must not be null, id is added as the last key when missing
+// Cursors of another order or signed with another key are xrescode.InvalidCursor
+func FindByCursor[T any](ctx context.Context, db *gorm.DB, req list.CursorRequest, sorts []query.SortCond, scopes ...ScopeFunc) (*list.CursorPage[T], error) {
+	req.Default()
+	keys, err := query.Keyset(sorts)
+	if err != nil {
+		return nil, err
+	}
+	var entities []T
+	tx := WithContext(ctx, db)
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(&entities); err != nil {
+		return nil, err
+	}
+	fields := make([]*schema.Field, len(keys))
+	for i, key := range keys {
+		if fields[i] = stmt.Schema.LookUpField(query.Column(key.Key)); fields[i] == nil {
+			return nil, fmt.Errorf("xrepo: sort key %s is not a field of %s", key.Key, stmt.Schema.Name)
+		}
+	}
+	order := query.KeysetName(keys)
+	var cursor list.Cursor
+	if req.Cursor != "" {
+		if cursor, err = list.DecodeCursor(req.Cursor); err != nil {
+			return nil, xrescode.InvalidCursor(err)
+		}
+		values, err := cursorValues(cursor, order, fields)
+		if err != nil {
+			return nil, xrescode.InvalidCursor(err)
+		}
+		where, args := query.After(keys, values, cursor.Backward)
+		tx = tx.Where(where, args...)
+	}
+	limit := req.LimitValue()
+	// one more row tells whether the page has a neighbour in its direction
+	err = tx.Scopes(scopes...).Scopes(query.SortKeyset(keys, cursor.Backward)).Limit(limit + 1).Find(&entities).Error
+	if err != nil {
+		return nil, xrescode.Classify(err)
+	}
+	more := len(entities) > limit
+	if more {
+		entities = entities[:limit]
+	}
+	if cursor.Backward {
+		slices.Reverse(entities)
+	}
+	page := &list.CursorPage[T]{Items: entities, Limit: limit}
+	if len(entities) == 0 {
+		page.Items = []T{}
+		return page, nil
+	}
+	// a backward page always has the rows it came from after it, a forward one those before it
+	if more || cursor.Backward {
+		if page.NextCursor, err = encodeCursor(ctx, entities[len(entities)-1], order, fields, false); err != nil {
+			return nil, err
+		}
+	}
+	if (more && cursor.Backward) || (req.Cursor != "" && !cursor.Backward) {
+		if page.PrevCursor, err = encodeCursor(ctx, entities[0], order, fields, true); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// cursorValues decodes the values of a cursor into the types of the sort key fields
+func cursorValues(cursor list.Cursor, order string, fields []*schema.Field) ([]any, error) {
+	if cursor.Order != order || len(cursor.Values) != len(fields) {
+		return nil, fmt.Errorf("xrepo: cursor of order %q used for %q", cursor.Order, order)
+	}
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		v := reflect.New(field.FieldType)
+		if err := json.Unmarshal(cursor.Values[i], v.Interface()); err != nil {
+			return nil, err
+		}
+		values[i] = v.Elem().Interface()
+	}
+	return values, nil
+}
+
+// encodeCursor returns the cursor of the page starting from entity
+func encodeCursor(ctx context.Context, entity any, order string, fields []*schema.Field, backward bool) (string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(entity))
+	cursor := list.Cursor{Order: order, Values: make([]json.RawMessage, len(fields)), Backward: backward}
+	for i, field := range fields {
+		v, _ := field.ValueOf(ctx, rv)
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		cursor.Values[i] = raw
+	}
+	return list.EncodeCursor(cursor)
+}
+
 func Save[T any](ctx context.Context, db *gorm.DB, entity *T, id uuid.UUID) error {
 	if id == uuid.Nil {
-		return WithContext(ctx, db).Create(entity).Error
+		return xrescode.Classify(WithContext(ctx, db).Create(entity).Error)
 	}
-	return WithContext(ctx, db).Where("id = ?", id).Save(entity).Error
+	return xrescode.Classify(WithContext(ctx, db).Where("id = ?", id).Save(entity).Error)
 }
@@ -0,0 +1,139 @@
+package xrepo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/salihguru/idiogo/pkg/list"
+	"github.com/salihguru/idiogo/pkg/ptr"
+	"github.com/salihguru/idiogo/pkg/query"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recorder is a database/sql driver recording the queries it runs
+// Counts return total, every other query returns no rows
+type recorder struct {
+	mu      sync.Mutex
+	total   int64
+	queries []string
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) { return r, nil }
+
+func (r *recorder) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (r *recorder) Close() error                        { return nil }
+func (r *recorder) Begin() (driver.Tx, error)           { return r, nil }
+func (r *recorder) Commit() error                       { return nil }
+func (r *recorder) Rollback() error                     { return nil }
+
+func (r *recorder) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) { return r, nil }
+
+func (r *recorder) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries = append(r.queries, query)
+	if strings.Contains(query, "count(*)") {
+		return &rows{cols: []string{"count"}, values: [][]driver.Value{{r.total}}}, nil
+	}
+	return &rows{cols: []string{"id"}}, nil
+}
+
+type rows struct {
+	cols   []string
+	values [][]driver.Value
+}
+
+func (r *rows) Columns() []string { return r.cols }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// newRecorder returns a postgres gorm.DB running its queries on a recorder
+func newRecorder(t *testing.T, total int64) (*gorm.DB, *recorder) {
+	t.Helper()
+	rec := &recorder{total: total}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(connector{rec})}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, rec
+}
+
+type connector struct{ rec *recorder }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.rec, nil }
+func (c connector) Driver() driver.Driver                        { return c.rec }
+
+type entity struct {
+	ID    int64
+	Title string
+}
+
+func TestFindPaged(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		page    int
+		order   ScopeFunc
+		queries []string
+	}{
+		{
+			name:  "ordered page",
+			total: 25,
+			page:  2,
+			order: query.OrderBy([]query.SortCond{query.SortBasic("title", query.SortAsc, false)}),
+			queries: []string{
+				`SELECT count(*) FROM "entities" WHERE title = $1`,
+				`SELECT * FROM "entities" WHERE title = $1 ORDER BY title ASC LIMIT $2 OFFSET $3`,
+			},
+		},
+		{
+			name:  "without order",
+			total: 25,
+			page:  1,
+			queries: []string{
+				`SELECT count(*) FROM "entities" WHERE title = $1`,
+				`SELECT * FROM "entities" WHERE title = $1 LIMIT $2`,
+			},
+		},
+		{
+			name:    "past the last page",
+			total:   5,
+			page:    2,
+			order:   query.SortDirect("title"),
+			queries: []string{`SELECT count(*) FROM "entities" WHERE title = $1`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := newRecorder(t, tt.total)
+			pagi := list.PagiRequest{Page: ptr.Int(tt.page), Limit: ptr.Int(10)}
+			page, err := FindPaged[entity](context.Background(), db, pagi, tt.order, query.Apply([]query.Conds{query.Eq("title", "milk")}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.Total != tt.total {
+				t.Errorf("total = %d, want %d", page.Total, tt.total)
+			}
+			if strings.Join(rec.queries, "\n") != strings.Join(tt.queries, "\n") {
+				t.Errorf("queries =\n%s\nwant\n%s", strings.Join(rec.queries, "\n"), strings.Join(tt.queries, "\n"))
+			}
+		})
+	}
+}
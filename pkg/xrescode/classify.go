@@ -0,0 +1,68 @@
+package xrescode
+
+import (
+	"context"
+	"errors"
+
+	"github.com/restayway/rescode"
+	"gorm.io/gorm"
+)
+
+// Postgres SQLSTATE codes mapped by Classify
+// see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	sqlStateNotNullViolation     = "23502"
+	sqlStateForeignKeyViolation  = "23503"
+	sqlStateUniqueViolation      = "23505"
+	sqlStateCheckViolation       = "23514"
+	sqlStateExclusionViolation   = "23P01"
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+	sqlStateQueryCanceled        = "57014"
+)
+
+// sqlStateError is implemented by both *pq.Error and *pgconn.PgError
+type sqlStateError interface {
+	SQLState() string
+}
+
+// Classify maps repository and database errors to results, wrapping the original error
+// Results and unknown errors are returned as is, nil stays nil
+//
+//	gorm.ErrRecordNotFound                 -> NotFound
+//	unique violation                       -> Conflict
+//	foreign key violation                  -> InvalidReference
+//	check, not null, exclusion violation   -> ConstraintViolation
+//	serialization failure, deadlock        -> Retry
+//	context deadline, query canceled       -> Timeout
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	var rc *rescode.RC
+	if errors.As(err, &rc) {
+		return err
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound(err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Timeout(err)
+	}
+	var pgErr sqlStateError
+	if errors.As(err, &pgErr) {
+		switch pgErr.SQLState() {
+		case sqlStateUniqueViolation:
+			return Conflict(err)
+		case sqlStateForeignKeyViolation:
+			return InvalidReference(err)
+		case sqlStateCheckViolation, sqlStateNotNullViolation, sqlStateExclusionViolation:
+			return ConstraintViolation(err)
+		case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+			return Retry(err)
+		case sqlStateQueryCanceled:
+			return Timeout(err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package xrescode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/restayway/rescode"
+	"gorm.io/gorm"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want uint64
+	}{
+		{name: "record not found", err: gorm.ErrRecordNotFound, want: NotFoundCode},
+		{name: "wrapped record not found", err: fmt.Errorf("view: %w", gorm.ErrRecordNotFound), want: NotFoundCode},
+		{name: "deadline", err: context.DeadlineExceeded, want: TimeoutCode},
+		{name: "pq unique", err: &pq.Error{Code: "23505"}, want: ConflictCode},
+		{name: "pgx unique", err: &pgconn.PgError{Code: "23505"}, want: ConflictCode},
+		{name: "pgx foreign key", err: &pgconn.PgError{Code: "23503"}, want: InvalidReferenceCode},
+		{name: "pq check", err: &pq.Error{Code: "23514"}, want: ConstraintViolationCode},
+		{name: "pgx not null", err: &pgconn.PgError{Code: "23502"}, want: ConstraintViolationCode},
+		{name: "pgx serialization", err: &pgconn.PgError{Code: "40001"}, want: RetryCode},
+		{name: "pq deadlock", err: &pq.Error{Code: "40P01"}, want: RetryCode},
+		{name: "pgx query canceled", err: &pgconn.PgError{Code: "57014"}, want: TimeoutCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Classify(tt.err)
+			var rc *rescode.RC
+			if !errors.As(got, &rc) {
+				t.Fatalf("Classify() = %v, want a result", got)
+			}
+			if rc.Code != tt.want {
+				t.Errorf("Classify() code = %d, want %d", rc.Code, tt.want)
+			}
+			if !errors.Is(rc.OriginalError(), tt.err) {
+				t.Error("Classify() does not wrap the original error")
+			}
+		})
+	}
+}
+
+func TestClassifyKeeps(t *testing.T) {
+	if Classify(nil) != nil {
+		t.Error("Classify(nil) != nil")
+	}
+	unknown := errors.New("unknown")
+	if Classify(unknown) != unknown {
+		t.Error("Classify() changed an unknown error")
+	}
+	rc := Conflict()
+	if Classify(rc) != error(rc) {
+		t.Error("Classify() changed a result")
+	}
+	if err := Classify(&pgconn.PgError{Code: "42P01"}); err == nil {
+		t.Error("Classify() dropped an unmapped database error")
+	}
+}
@@ -0,0 +1,90 @@
+package xrescode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/restayway/rescode"
+	"go.yaml.in/yaml/v2"
+)
+
+// Result is a result code definition, as written in a result.yml
+type Result struct {
+	Code    uint64 `yaml:"code"`
+	Key     string `yaml:"key"`
+	Message string `yaml:"message"`
+	HTTP    int    `yaml:"http"`
+	GRPC    int    `yaml:"grpc"`
+
+	// Domain is the package that registered the result
+	Domain string `yaml:"-"`
+}
+
+var registry struct {
+	mu      sync.Mutex
+	results map[uint64]Result
+	errs    []error
+}
+
+// Register adds the results of a domain to the registry
+// Conflicts are not fatal here, they are reported by Check on startup
+func Register(domain string, results ...Result) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if registry.results == nil {
+		registry.results = make(map[uint64]Result)
+	}
+	for _, r := range results {
+		r.Domain = domain
+		if existing, ok := registry.results[r.Code]; ok {
+			registry.errs = append(registry.errs, fmt.Errorf("xrescode: code %d of %s.%s is already used by %s.%s", r.Code, domain, r.Key, existing.Domain, existing.Key))
+			continue
+		}
+		registry.results[r.Code] = r
+	}
+}
+
+// RegisterYAML registers the results of a domain result.yml, usually embedded next to its generated code
+// example:
+//
+//	//go:embed result.yml
+//	var results []byte
+//
+//	func init() { xrescode.RegisterYAML("todo", results) }
+func RegisterYAML(domain string, data []byte) {
+	var results []Result
+	if err := yaml.Unmarshal(data, &results); err != nil {
+		registry.mu.Lock()
+		registry.errs = append(registry.errs, fmt.Errorf("xrescode: %s result.yml: %w", domain, err))
+		registry.mu.Unlock()
+		return
+	}
+	Register(domain, results...)
+}
+
+// Results returns every registered result ordered by code
+func Results() []Result {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	results := make([]Result, 0, len(registry.results))
+	for _, r := range registry.results {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Code < results[j].Code })
+	return results
+}
+
+// Check reports the duplicate codes and invalid result files seen by Register
+func Check() error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return errors.Join(registry.errs...)
+}
+
+// Is reports whether err is, or wraps, the result with the given code
+func Is(err error, code uint64) bool {
+	var rc *rescode.RC
+	return errors.As(err, &rc) && rc.Code == code
+}
@@ -0,0 +1,39 @@
+package xrescode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryBase(t *testing.T) {
+	results := Results()
+	if len(results) == 0 || results[0].Code != ValidationFailedCode || results[0].Domain != "base" {
+		t.Fatalf("Results() = %v, want the base results first", results)
+	}
+	if err := Check(); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+}
+
+func TestRegistryDuplicate(t *testing.T) {
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		registry.errs = nil
+		registry.mu.Unlock()
+	})
+	Register("dup", Result{Code: NotFoundCode, Key: "Missing", Message: "dup_missing"})
+
+	err := Check()
+	if err == nil || !strings.Contains(err.Error(), "dup.Missing") || !strings.Contains(err.Error(), "base.NotFound") {
+		t.Errorf("Check() = %v, want the duplicate code", err)
+	}
+}
+
+func TestIs(t *testing.T) {
+	if !Is(NotFound(), NotFoundCode) {
+		t.Error("Is() = false for the same code")
+	}
+	if Is(Conflict(), NotFoundCode) || Is(nil, NotFoundCode) {
+		t.Error("Is() = true for another code")
+	}
+}
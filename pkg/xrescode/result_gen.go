@@ -23,6 +23,36 @@ const (
 	NotFoundHTTP int        = 404
 	NotFoundGRPC codes.Code = 5
 	NotFoundMsg  string     = "base_not_found"
+
+	ConflictCode uint64     = 1003
+	ConflictHTTP int        = 409
+	ConflictGRPC codes.Code = 6
+	ConflictMsg  string     = "base_conflict"
+
+	InvalidReferenceCode uint64     = 1004
+	InvalidReferenceHTTP int        = 409
+	InvalidReferenceGRPC codes.Code = 9
+	InvalidReferenceMsg  string     = "base_invalid_reference"
+
+	ConstraintViolationCode uint64     = 1005
+	ConstraintViolationHTTP int        = 422
+	ConstraintViolationGRPC codes.Code = 3
+	ConstraintViolationMsg  string     = "base_constraint_violation"
+
+	RetryCode uint64     = 1006
+	RetryHTTP int        = 409
+	RetryGRPC codes.Code = 10
+	RetryMsg  string     = "base_retry"
+
+	TimeoutCode uint64     = 1007
+	TimeoutHTTP int        = 504
+	TimeoutGRPC codes.Code = 4
+	TimeoutMsg  string     = "base_timeout"
+
+	InvalidCursorCode uint64     = 1008
+	InvalidCursorHTTP int        = 400
+	InvalidCursorGRPC codes.Code = 3
+	InvalidCursorMsg  string     = "base_invalid_cursor"
 )
 
 // ValidationFailed creates a new ValidationFailed error.
@@ -39,3 +69,33 @@ func Failed(err ...error) *rescode.RC {
 func NotFound(err ...error) *rescode.RC {
 	return rescode.New(NotFoundCode, NotFoundHTTP, NotFoundGRPC, NotFoundMsg)(err...)
 }
+
+// Conflict creates a new Conflict error.
+func Conflict(err ...error) *rescode.RC {
+	return rescode.New(ConflictCode, ConflictHTTP, ConflictGRPC, ConflictMsg)(err...)
+}
+
+// InvalidReference creates a new InvalidReference error.
+func InvalidReference(err ...error) *rescode.RC {
+	return rescode.New(InvalidReferenceCode, InvalidReferenceHTTP, InvalidReferenceGRPC, InvalidReferenceMsg)(err...)
+}
+
+// ConstraintViolation creates a new ConstraintViolation error.
+func ConstraintViolation(err ...error) *rescode.RC {
+	return rescode.New(ConstraintViolationCode, ConstraintViolationHTTP, ConstraintViolationGRPC, ConstraintViolationMsg)(err...)
+}
+
+// Retry creates a new Retry error.
+func Retry(err ...error) *rescode.RC {
+	return rescode.New(RetryCode, RetryHTTP, RetryGRPC, RetryMsg)(err...)
+}
+
+// Timeout creates a new Timeout error.
+func Timeout(err ...error) *rescode.RC {
+	return rescode.New(TimeoutCode, TimeoutHTTP, TimeoutGRPC, TimeoutMsg)(err...)
+}
+
+// InvalidCursor creates a new InvalidCursor error.
+func InvalidCursor(err ...error) *rescode.RC {
+	return rescode.New(InvalidCursorCode, InvalidCursorHTTP, InvalidCursorGRPC, InvalidCursorMsg)(err...)
+}
@@ -0,0 +1,10 @@
+package xrescode
+
+import _ "embed"
+
+//go:embed result.yml
+var results []byte
+
+func init() {
+	RegisterYAML("base", results)
+}
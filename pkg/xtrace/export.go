@@ -0,0 +1,71 @@
+package xtrace
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// SpanData is a finished span as handed to exporters
+type SpanData struct {
+	TraceID  string
+	SpanID   string
+	ParentID string
+	Name     string
+	Service  string
+	Start    time.Time
+	End      time.Time
+	Attrs    []Attr
+	Error    string
+}
+
+// Exporter receives finished spans, Export must be safe for concurrent use
+type Exporter interface {
+	Export(SpanData)
+	Shutdown(ctx context.Context) error
+}
+
+// WriterExporter writes every span as a JSON line in the OTLP/JSON file format
+// Each line is an ExportTraceServiceRequest, so OpenTelemetry collectors and tools can read the file
+type WriterExporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	c   io.Closer
+}
+
+// NewWriterExporter writes spans to w, e.g. os.Stdout
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{enc: json.NewEncoder(w)}
+}
+
+// NewFileExporter appends spans to the file at path
+func NewFileExporter(path string) (*WriterExporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	e := NewWriterExporter(f)
+	e.c = f
+	return e, nil
+}
+
+func (e *WriterExporter) Export(s SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_ = e.enc.Encode(otlpRequest(s))
+}
+
+// Shutdown closes the underlying file, if any
+func (e *WriterExporter) Shutdown(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.c == nil {
+		return nil
+	}
+	err := e.c.Close()
+	e.c = nil
+	return err
+}
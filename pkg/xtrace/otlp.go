@@ -0,0 +1,114 @@
+package xtrace
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// scopeName is the instrumentation scope of every exported span
+const scopeName = "github.com/salihguru/idiogo/pkg/xtrace"
+
+// statusError is the STATUS_CODE_ERROR of an OTLP span status
+const statusError = 2
+
+// The types below are the parts of the OTLP/JSON ExportTraceServiceRequest written by the exporters
+// Ids are lower case hex and 64 bit integers are decimal strings as protobuf JSON maps them
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// otlpRequest wraps the span in an ExportTraceServiceRequest of its service
+func otlpRequest(s SpanData) otlpTraces {
+	span := otlpSpan{
+		TraceID:           s.TraceID,
+		SpanID:            s.SpanID,
+		ParentSpanID:      s.ParentID,
+		Name:              s.Name,
+		StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+	}
+	for _, a := range s.Attrs {
+		span.Attributes = append(span.Attributes, otlpAttr(a.Key, a.Value))
+	}
+	if s.Error != "" {
+		span.Status = &otlpStatus{Code: statusError, Message: s.Error}
+	}
+	var resource otlpResource
+	if s.Service != "" {
+		resource.Attributes = []otlpKeyValue{otlpAttr("service.name", s.Service)}
+	}
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource:   resource,
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}, Spans: []otlpSpan{span}}},
+	}}}
+}
+
+// otlpAttr converts an attribute to its typed OTLP value, other types are written as strings
+func otlpAttr(key string, value any) otlpKeyValue {
+	var v otlpValue
+	switch n := value.(type) {
+	case string:
+		v.StringValue = &n
+	case bool:
+		v.BoolValue = &n
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+		s := fmt.Sprint(n)
+		v.IntValue = &s
+	case float32:
+		f := float64(n)
+		v.DoubleValue = &f
+	case float64:
+		v.DoubleValue = &n
+	default:
+		s := fmt.Sprint(n)
+		v.StringValue = &s
+	}
+	return otlpKeyValue{Key: key, Value: v}
+}
@@ -0,0 +1,279 @@
+package xtrace
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HeaderTraceparent is the W3C trace context header
+const HeaderTraceparent = "traceparent"
+
+var ErrInvalidTraceparent = errors.New("xtrace: invalid traceparent")
+
+type TraceID [16]byte
+
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+type SpanID [8]byte
+
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext identifies a span across process boundaries
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats the span context as a version 00 traceparent header value
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent parses a W3C traceparent header value
+func ParseTraceparent(s string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return sc, ErrInvalidTraceparent
+	}
+	if !decodeHex(sc.TraceID[:], parts[1]) || !decodeHex(sc.SpanID[:], parts[2]) || len(parts[3]) != 2 {
+		return sc, ErrInvalidTraceparent
+	}
+	var flags [1]byte
+	if !decodeHex(flags[:], parts[3]) || !sc.IsValid() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, nil
+}
+
+func decodeHex(dst []byte, s string) bool {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+// Attr is a span attribute
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// Span is a timed operation, a nil span is valid and records nothing
+type Span struct {
+	tracer *Tracer
+	sc     SpanContext
+	parent SpanID
+
+	mu    sync.Mutex
+	name  string
+	start time.Time
+	attrs []Attr
+	err   string
+	ended bool
+}
+
+// Context returns the span context, the zero value for a nil span
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetName renames the span, e.g. once the route is matched
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.name = name
+	s.mu.Unlock()
+}
+
+// SetAttr adds an attribute to the span
+func (s *Span) SetAttr(key string, value any) {
+	if s == nil || !s.sc.Sampled {
+		return
+	}
+	s.mu.Lock()
+	s.attrs = append(s.attrs, Attr{Key: key, Value: value})
+	s.mu.Unlock()
+}
+
+// RecordError marks the span as failed, nil errors are ignored
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.err = err.Error()
+	s.mu.Unlock()
+}
+
+// End finishes the span and exports it if sampled, only the first call has an effect
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	data := SpanData{
+		TraceID: s.sc.TraceID.String(),
+		SpanID:  s.sc.SpanID.String(),
+		Name:    s.name,
+		Service: s.tracer.cnf.Service,
+		Start:   s.start,
+		End:     time.Now(),
+		Attrs:   s.attrs,
+		Error:   s.err,
+	}
+	s.mu.Unlock()
+	if s.parent.IsValid() {
+		data.ParentID = s.parent.String()
+	}
+	if s.sc.Sampled {
+		s.tracer.cnf.Exporter.Export(data)
+	}
+}
+
+type spanKey struct{}
+
+type remoteKey struct{}
+
+// ContextWithSpan returns a context carrying the span
+func ContextWithSpan(ctx context.Context, s *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, s)
+}
+
+// SpanFrom returns the span of the context or nil
+func SpanFrom(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// ContextWithRemote returns a context whose next span continues the remote trace
+func ContextWithRemote(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// TraceIDFrom returns the trace id of the context span or an empty string
+func TraceIDFrom(ctx context.Context) string {
+	if sc := SpanFrom(ctx).Context(); sc.IsValid() {
+		return sc.TraceID.String()
+	}
+	return ""
+}
+
+// Extract continues the trace of a traceparent header read with get
+// Invalid or missing headers leave ctx unchanged
+func Extract(ctx context.Context, get func(key string) string) context.Context {
+	sc, err := ParseTraceparent(get(HeaderTraceparent))
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemote(ctx, sc)
+}
+
+// Inject writes the traceparent header of the context span with set
+func Inject(ctx context.Context, set func(key, value string)) {
+	if sc := SpanFrom(ctx).Context(); sc.IsValid() {
+		set(HeaderTraceparent, sc.Traceparent())
+	}
+}
+
+// Config configures a tracer
+type Config struct {
+	// Service is the service name added to every exported span
+	Service string
+
+	// SampleRate is the share of new traces recorded, traces continued from a remote parent follow its decision
+	SampleRate float64
+
+	// Exporter receives every sampled span
+	Exporter Exporter
+}
+
+// Tracer starts spans and hands the finished ones to its exporter
+type Tracer struct {
+	cnf Config
+}
+
+// New creates a tracer, a nil exporter disables tracing
+func New(cnf Config) *Tracer {
+	return &Tracer{cnf: cnf}
+}
+
+// Start opens a span as a child of the context span or remote parent
+// It returns ctx unchanged and a nil span when tracing is disabled
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	if t == nil || t.cnf.Exporter == nil {
+		return ctx, nil
+	}
+	span := &Span{tracer: t, name: name, start: time.Now()}
+	if parent := SpanFrom(ctx); parent != nil {
+		span.sc = SpanContext{TraceID: parent.sc.TraceID, Sampled: parent.sc.Sampled}
+		span.parent = parent.sc.SpanID
+	} else if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		span.sc = SpanContext{TraceID: remote.TraceID, Sampled: remote.Sampled}
+		span.parent = remote.SpanID
+	} else {
+		_, _ = crand.Read(span.sc.TraceID[:])
+		span.sc.Sampled = rand.Float64() < t.cnf.SampleRate
+	}
+	_, _ = crand.Read(span.sc.SpanID[:])
+	return ContextWithSpan(ctx, span), span
+}
+
+var defaultTracer atomic.Pointer[Tracer]
+
+// SetDefault makes t the tracer used by Start
+func SetDefault(t *Tracer) {
+	defaultTracer.Store(t)
+}
+
+// Start opens a span with the default tracer
+// example:
+//
+//	ctx, span := xtrace.Start(ctx, "todo.Create")
+//	defer span.End()
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	return defaultTracer.Load().Start(ctx, name)
+}
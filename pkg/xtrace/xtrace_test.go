@@ -0,0 +1,144 @@
+package xtrace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memExporter struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+func (m *memExporter) Export(s SpanData) {
+	m.mu.Lock()
+	m.spans = append(m.spans, s)
+	m.mu.Unlock()
+}
+
+func (m *memExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		in      string
+		sampled bool
+		wantErr bool
+	}{
+		{in: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sampled: true},
+		{in: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{in: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", wantErr: true},
+		{in: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", wantErr: true},
+		{in: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", wantErr: true},
+		{in: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantErr: true},
+		{in: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		sc, err := ParseTraceparent(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTraceparent(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if sc.Sampled != tt.sampled {
+			t.Errorf("ParseTraceparent(%q) sampled = %v, want %v", tt.in, sc.Sampled, tt.sampled)
+		}
+		if got := sc.Traceparent(); got != tt.in {
+			t.Errorf("Traceparent() = %q, want %q", got, tt.in)
+		}
+	}
+}
+
+func TestStartContinuesRemoteTrace(t *testing.T) {
+	exp := &memExporter{}
+	tr := New(Config{Service: "test", SampleRate: 0, Exporter: exp})
+	header := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := Extract(context.Background(), func(string) string { return header })
+
+	ctx, parent := tr.Start(ctx, "parent")
+	_, child := tr.Start(ctx, "child")
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+	parent.End()
+
+	if len(exp.spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(exp.spans))
+	}
+	c, p := exp.spans[0], exp.spans[1]
+	if p.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || c.TraceID != p.TraceID {
+		t.Errorf("trace ids = %s, %s, want the remote trace id", p.TraceID, c.TraceID)
+	}
+	if p.ParentID != "00f067aa0ba902b7" || c.ParentID != p.SpanID {
+		t.Errorf("parent ids = %s, %s, want the remote span and the parent span", p.ParentID, c.ParentID)
+	}
+	if c.Error != "boom" {
+		t.Errorf("child error = %q, want boom", c.Error)
+	}
+	if got := TraceIDFrom(ctx); got != p.TraceID {
+		t.Errorf("TraceIDFrom() = %q, want %q", got, p.TraceID)
+	}
+}
+
+func TestStartSampling(t *testing.T) {
+	exp := &memExporter{}
+	tr := New(Config{SampleRate: 0, Exporter: exp})
+	ctx, span := tr.Start(context.Background(), "dropped")
+	span.End()
+	if len(exp.spans) != 0 {
+		t.Errorf("exported %d spans of an unsampled trace", len(exp.spans))
+	}
+	if TraceIDFrom(ctx) == "" {
+		t.Error("unsampled span has no trace id")
+	}
+}
+
+func TestDisabledTracer(t *testing.T) {
+	ctx := context.Background()
+	got, span := New(Config{}).Start(ctx, "noop")
+	if got != ctx || span != nil {
+		t.Error("Start() without exporter should return ctx and a nil span")
+	}
+	span.SetAttr("k", "v")
+	span.End()
+}
+
+func TestWriterExporter(t *testing.T) {
+	var buf bytes.Buffer
+	start := time.Unix(1700000000, 5)
+	NewWriterExporter(&buf).Export(SpanData{
+		TraceID:  "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:   "00f067aa0ba902b7",
+		ParentID: "00f067aa0ba902b6",
+		Name:     "GET /todos",
+		Service:  "test",
+		Start:    start,
+		End:      start.Add(time.Millisecond),
+		Attrs: []Attr{
+			{Key: "http.route", Value: "/todos"},
+			{Key: "http.status_code", Value: 500},
+			{Key: "cached", Value: true},
+			{Key: "ratio", Value: 0.5},
+		},
+		Error: "boom",
+	})
+	want := `{"resourceSpans":[{` +
+		`"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"test"}}]},` +
+		`"scopeSpans":[{"scope":{"name":"github.com/salihguru/idiogo/pkg/xtrace"},"spans":[{` +
+		`"traceId":"4bf92f3577b34da6a3ce929d0e0e4736","spanId":"00f067aa0ba902b7","parentSpanId":"00f067aa0ba902b6",` +
+		`"name":"GET /todos","startTimeUnixNano":"1700000000000000005","endTimeUnixNano":"1700000000001000005",` +
+		`"attributes":[{"key":"http.route","value":{"stringValue":"/todos"}},{"key":"http.status_code","value":{"intValue":"500"}},` +
+		`{"key":"cached","value":{"boolValue":true}},{"key":"ratio","value":{"doubleValue":0.5}}],` +
+		`"status":{"code":2,"message":"boom"}}]}]}]}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Export() wrote\n%s\nwant\n%s", got, want)
+	}
+}